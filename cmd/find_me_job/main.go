@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/AliUmarov/team-find-me-job/internal/config"
-	"github.com/AliUmarov/team-find-me-job/internal/gigachat"
+	"github.com/AliUmarov/team-find-me-job/internal/events"
 	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/repository"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/AliUmarov/team-find-me-job/internal/transport"
 	"github.com/AliUmarov/team-find-me-job/internal/validators"
+	"github.com/AliUmarov/team-find-me-job/internal/workers"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,13 +30,50 @@ func main() {
 	config.SetEnv(log)
 	db := config.Connect(log)
 
+	// Refresh-токены раньше ссылались только на applicants; теперь владельцем
+	// может быть и компания, поэтому старый внешний ключ больше не нужен.
+	if db.Migrator().HasConstraint(&models.RefreshToken{}, "fk_refresh_tokens_user") {
+		if err := db.Migrator().DropConstraint(&models.RefreshToken{}, "fk_refresh_tokens_user"); err != nil {
+			log.Error("failed to drop refresh token constraint", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Вилка зарплаты заменила одно поле salary: данные переносим после
+	// миграции, пока старая колонка ещё есть.
+	importLegacySalary := db.Migrator().HasColumn(&models.Vacancy{}, "salary")
+
 	if err := db.AutoMigrate(
 		&models.Company{},
 		&models.Vacancy{},
 		&models.Resume{},
+		&models.ResumeVersion{},
+		&models.WorkExperience{},
+		&models.Education{},
+		&models.ResumeSkill{},
+		&models.Language{},
 		&models.Applicant{},
 		&models.Application{},
+		&models.ApplicationStatusChange{},
 		&models.RefreshToken{},
+		&models.Session{},
+		&models.OneTimeToken{},
+		&models.OutboxEmail{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.Message{},
+		&models.MessageAttachment{},
+		&models.InterviewSlot{},
+		&models.Interview{},
+		&models.CompanyReview{},
+		&models.CompanyMember{},
+		&models.VacancyRecruiter{},
+		&models.Admin{},
+		&models.AdminAction{},
+		&models.SavedVacancy{},
+		&models.SavedSearch{},
+		&models.AIJob{},
+		&models.DataMigration{},
 	); err != nil {
 		log.Error("failed to migrate database", "error", err)
 		os.Exit(1)
@@ -41,43 +86,123 @@ func main() {
 		port = "8080"
 	}
 
-	tokenProvider, err := gigachat.NewTokenProvider()
+	llm, err := config.NewLLMProvider(log)
 	if err != nil {
-		log.Error("failed to init GigaChat TokenProvider", slog.Any("error", err))
+		log.Error("failed to init LLM provider", slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	gigaClient, err := gigachat.NewClient(tokenProvider)
+	mailTransport, err := config.NewMailTransport(log)
 	if err != nil {
-		log.Error("failed to init GigaChat client", slog.Any("error", err))
+		log.Error("failed to init mail transport", slog.Any("error", err))
 		os.Exit(1)
 	}
 
 	companyRepo := repository.NewCompanyRepository(db)
 	applicantRepo := repository.NewApplicantRepository(db, log)
 	vacancyRepo := repository.NewVacancyRepository(db)
+	if importLegacySalary {
+		imported, err := vacancyRepo.ImportLegacySalary()
+		if err != nil {
+			log.Error("failed to import legacy vacancy salaries", slog.Any("error", err))
+			os.Exit(1)
+		}
+		log.Info("legacy vacancy salaries imported", slog.Int64("count", imported))
+	}
 	resumeRepo := repository.NewResumeRepository(db, log)
+	// Структурированные навыки появились позже текстового поля skills:
+	// переносим в них старые данные. Повторный запуск ничего не делает.
+	imported, err := resumeRepo.ImportLegacySkills()
+	if err != nil {
+		log.Error("failed to import legacy resume skills", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if imported > 0 {
+		log.Info("legacy resume skills imported", slog.Int64("count", imported))
+	}
 	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
-
-	jwtService := services.NewJWTService()
-	authService := services.NewAuthService(applicantRepo, companyRepo, log, refreshTokenRepo, jwtService, db)
+	sessionRepo := repository.NewSessionRepository(db)
+	oneTimeTokenRepo := repository.NewOneTimeTokenRepository(db)
+	memberRepo := repository.NewMemberRepository(db)
+	adminRepo := repository.NewAdminRepository(db)
+	aiJobRepo := repository.NewAIJobRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+
+	notificationService := services.NewNotificationService(notificationRepo, outboxRepo, applicantRepo, companyRepo, vacancyRepo, resumeRepo, memberRepo, log)
+
+	broker := events.NewMemoryBroker(log)
+
+	jwtService := services.NewJWTService(config.JWTSecret(log))
+	authService := services.NewAuthService(
+		applicantRepo, companyRepo, memberRepo, adminRepo, log, refreshTokenRepo, sessionRepo, oneTimeTokenRepo, notificationService, jwtService, db,
+	)
 	applicationRepo := repository.NewApplicationRepository(db)
 
+	interviewService := services.NewInterviewService(
+		repository.NewInterviewRepository(db), applicationRepo, vacancyRepo, companyRepo, applicantRepo, memberRepo, notificationService, broker,
+	)
+
 	applicantService := services.NewApplicantService(applicantRepo, log)
-	resumeService := services.NewResumeService(resumeRepo, applicantRepo, log, gigaClient)
-	companyService := services.NewCompanyService(companyRepo, vacancyRepo, applicationRepo)
+	resumeService := services.NewResumeService(resumeRepo, applicantRepo, aiJobRepo, log, llm, config.NewExportRenderer(), notificationService)
+	companyService := services.NewCompanyService(companyRepo, vacancyRepo, applicationRepo, memberRepo, notificationService, interviewService, broker)
 	vacancyService := services.NewVacancyService(vacancyRepo)
-	applicationService := services.NewApplicationService(applicationRepo, vacancyRepo, resumeRepo)
+	applicationService := services.NewApplicationService(applicationRepo, vacancyRepo, resumeRepo, aiJobRepo, memberRepo, log, llm, notificationService, interviewService, broker)
+	aiJobService := services.NewAIJobService(aiJobRepo)
+	reviewService := services.NewReviewService(repository.NewReviewRepository(db))
+	memberService := services.NewMemberService(memberRepo, companyRepo, vacancyRepo, oneTimeTokenRepo, notificationService, db)
+	adminService := services.NewAdminService(adminRepo, vacancyRepo, reviewService, jwtService)
+	savedService := services.NewSavedService(
+		repository.NewSavedVacancyRepository(db), repository.NewSavedSearchRepository(db), vacancyRepo, notificationService, db,
+	)
+
+	if email, password := config.AdminCredentials(log); email != "" {
+		if err := adminService.EnsureAdmin(context.Background(), email, password); err != nil {
+			log.Error("failed to create admin account", "error", err)
+			os.Exit(1)
+		}
+	}
+	messageService := services.NewMessageService(repository.NewMessageRepository(db), applicationRepo, memberRepo, broker, log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	aiWorkers, err := strconv.Atoi(os.Getenv("AI_WORKERS"))
+	if err != nil || aiWorkers <= 0 {
+		aiWorkers = 2
+	}
+	go workers.NewAIJobWorker(aiJobRepo, resumeService, applicationService, broker, log, aiWorkers).Run(ctx)
+	go workers.NewOutboxDispatcher(outboxRepo, mailTransport, log).Run(ctx)
+	go workers.NewTokenSweeper(refreshTokenRepo, sessionRepo, oneTimeTokenRepo, log).Run(ctx)
+	go workers.NewVacancyExpirer(vacancyRepo, log).Run(ctx)
+	go workers.NewSavedSearchDigester(savedService, log).Run(ctx)
 
 	r := gin.Default()
 	r.Use(middlewares.CORSMiddleware())
 
-	transport.RegisterRoutes(r, log, companyService, applicantService, resumeService, vacancyService, applicationService, authService)
+	transport.RegisterRoutes(r, log, companyService, applicantService, resumeService, vacancyService, applicationService, authService, aiJobService, notificationService, messageService, interviewService, reviewService, memberService, adminService, savedService, broker)
+
+	srv := &http.Server{Addr: ":" + port, Handler: r}
+
+	go func() {
+		<-ctx.Done()
+
+		// Потоки событий не завершаются сами: закрываем подписки,
+		// чтобы Shutdown не ждал их до таймаута.
+		broker.Close()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error("не удалось корректно остановить сервер", slog.Any("error", err))
+		}
+	}()
 
 	log.Info("server started",
 		slog.String("addr", port))
 
-	if err := r.Run(":" + port); err != nil {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Error("не удалось запустить сервер", slog.Any("error", err))
 	}
 }
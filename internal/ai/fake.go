@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// Fake — детерминированный провайдер без сети для тестов и локального
+// запуска. На каждую задачу отвечает заранее заданным текстом из Responses,
+// а для известных задач без заданного ответа собирает правдоподобный JSON.
+type Fake struct {
+	mutex     sync.Mutex
+	Responses map[string]string
+	Requests  []CompletionRequest
+}
+
+func NewFake() *Fake {
+	return &Fake{Responses: map[string]string{}}
+}
+
+func (f *Fake) Complete(_ context.Context, req CompletionRequest) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.Requests = append(f.Requests, req)
+
+	if response, ok := f.Responses[req.Task]; ok {
+		return response, nil
+	}
+
+	var response any
+	switch req.Task {
+	case TaskImproveResume:
+		improved := lastUserMessage(req)
+		response = ImproveResult{Improved: improved, Fields: ResumeFields{Summary: improved}, Score: 5}
+	case TaskExtractResume:
+		response = map[string]any{"summary": lastUserMessage(req), "salary": 0}
+	case TaskMatchResume:
+		response = MatchResult{Score: 50, Matched: []string{}, Missing: []string{}, Justification: "offline fake"}
+	default:
+		response = map[string]any{}
+	}
+
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+func lastUserMessage(req CompletionRequest) string {
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == RoleUser {
+			return strings.TrimSpace(req.Messages[i].Content)
+		}
+	}
+	return ""
+}
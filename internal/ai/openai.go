@@ -0,0 +1,103 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// OpenAIProvider ходит в любой сервер с OpenAI-совместимым
+// /chat/completions: OpenAI, llama.cpp server, Ollama, vLLM.
+type OpenAIProvider struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+func NewOpenAIProvider(baseURL, apiKey, model string) *OpenAIProvider {
+	return &OpenAIProvider{
+		http:    &http.Client{Timeout: RequestTimeout},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+	}
+}
+
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type openAIRequest struct {
+	Model          string                `json:"model"`
+	Messages       []Message             `json:"messages"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+func (p *OpenAIProvider) Complete(ctx context.Context, req CompletionRequest) (string, error) {
+	payload := openAIRequest{
+		Model:    p.model,
+		Messages: req.Messages,
+	}
+	if req.JSON {
+		payload.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.http.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("ошибка запроса к LLM: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &StatusError{Provider: "openai", StatusCode: resp.StatusCode, Body: string(respBytes)}
+	}
+
+	var apiResp openAIResponse
+	if err := json.Unmarshal(respBytes, &apiResp); err != nil {
+		return "", fmt.Errorf("не удалось разобрать ответ LLM: %w", err)
+	}
+
+	if len(apiResp.Choices) == 0 {
+		return "", fmt.Errorf("ответ пустой (choices=0)")
+	}
+
+	return apiResp.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,108 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Роли сообщений чата.
+const (
+	RoleSystem = "system"
+	RoleUser   = "user"
+)
+
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type CompletionRequest struct {
+	// Task — имя задачи (TaskImproveResume и т.п.). Модели не отправляется,
+	// нужно для логов и для выбора ответа в Fake.
+	Task     string
+	Messages []Message
+	// JSON просит провайдера вернуть ответ строго в виде JSON-объекта.
+	JSON bool
+}
+
+// RequestTimeout ограничивает один HTTP-запрос к модели, даже если
+// контекст вызывающего не задаёт дедлайна.
+const RequestTimeout = 60 * time.Second
+
+// LLMProvider — языковая модель с интерфейсом chat completion.
+type LLMProvider interface {
+	Complete(ctx context.Context, req CompletionRequest) (string, error)
+}
+
+// StatusError — неуспешный HTTP-ответ провайдера.
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: ошибка %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// IsRetryable сообщает, имеет ли смысл повторить запрос позже:
+// провайдер перегружен (429), упал (5xx) или не ответил вовремя.
+func IsRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	// Отмена — решение вызывающего, а не сбой провайдера. *url.Error
+	// с отменённым контекстом реализует net.Error, поэтому проверяем раньше.
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// CompleteJSON запрашивает у модели JSON и разбирает его в out.
+// Обёртку ```json ... ```, которую модели иногда добавляют, отбрасывает.
+func CompleteJSON(ctx context.Context, provider LLMProvider, req CompletionRequest, out any) error {
+	req.JSON = true
+
+	content, err := provider.Complete(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	content = stripCodeFence(content)
+	if content == "" {
+		return fmt.Errorf("пустой content в ответе модели")
+	}
+
+	if err := json.Unmarshal([]byte(content), out); err != nil {
+		return fmt.Errorf("не удалось распарсить JSON из content: %w; raw content: %s", err, content)
+	}
+
+	return nil
+}
+
+func stripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimPrefix(content, "json")
+	content = strings.TrimSuffix(content, "```")
+	return strings.TrimSpace(content)
+}
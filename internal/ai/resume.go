@@ -0,0 +1,248 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+)
+
+// Задачи, которые сервисы ставят модели.
+const (
+	TaskImproveResume = "resume_improve"
+	TaskMatchResume   = "resume_match"
+	TaskExtractResume = "resume_extract"
+)
+
+// maxImportText — сколько символов загруженного документа уходит модели.
+const maxImportText = 15000
+
+// ResumeFields — улучшенные моделью поля резюме.
+type ResumeFields struct {
+	Position   string `json:"position"`
+	Summary    string `json:"summary"`
+	Skills     string `json:"skills"`
+	Experience string `json:"experience"`
+	Portfolio  string `json:"portfolio"`
+}
+
+// ImproveResult — улучшенный текст резюме целиком, те же правки по полям
+// (чтобы их можно было принять в резюме) и оценка.
+type ImproveResult struct {
+	Improved string       `json:"improved"`
+	Fields   ResumeFields `json:"fields"`
+	Score    int          `json:"score"`
+}
+
+// MatchResult — оценка соответствия резюме вакансии.
+type MatchResult struct {
+	Score         int      `json:"score"`
+	Matched       []string `json:"matched"`
+	Missing       []string `json:"missing"`
+	Justification string   `json:"justification"`
+}
+
+func ImproveResume(ctx context.Context, provider LLMProvider, fullText string) (*ImproveResult, error) {
+	prompt := fmt.Sprintf(`
+Ты — помощник по улучшению резюме.
+
+1) Улучши текст резюме, сохранив факты, но сделав формулировки более профессиональными и читаемыми.
+2) Текст должен быть минимум 200 символов.
+3) Те же улучшения разложи по полям резюме: position, summary, skills, experience, portfolio.
+   Если поле в исходном резюме пустое, оставь его пустым.
+4) Дай оценку резюме по шкале от 1 до 10, где 10 — идеальное резюме для сильного кандидата.
+
+ОТВЕТ ВЕРНИ СТРОГО В ФОРМАТЕ JSON БЕЗ ОБЪЯСНЕНИЙ И ТЕКСТА ВОКРУГ. ПРИМЕР:
+{
+  "improved": "улучшенный текст",
+  "fields": {
+    "position": "должность",
+    "summary": "о себе",
+    "skills": "навыки",
+    "experience": "опыт",
+    "portfolio": "портфолио"
+  },
+  "score": 8
+}
+
+Вот текст резюме:
+
+%s
+`, fullText)
+
+	var result ImproveResult
+	err := CompleteJSON(ctx, provider, CompletionRequest{
+		Task:     TaskImproveResume,
+		Messages: []Message{{Role: RoleUser, Content: prompt}},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Improved == "" {
+		return nil, fmt.Errorf("модель не вернула поле improved")
+	}
+	if result.Score <= 0 || result.Score > 100 {
+		result.Score = 50
+	}
+
+	return &result, nil
+}
+
+func MatchResumeToVacancy(ctx context.Context, provider LLMProvider, resume models.Resume, vacancy models.Vacancy) (*MatchResult, error) {
+	prompt := fmt.Sprintf(`
+Ты — помощник рекрутера. Оцени, насколько кандидат подходит на вакансию.
+
+1) Сопоставь резюме с обязательными требованиями вакансии.
+2) Перечисли требования, которым кандидат соответствует (matched), и которым не соответствует (missing).
+   Используй формулировки требований из вакансии.
+3) Учитывай обязанности и пожелания (nice to have), но они весят меньше обязательных требований.
+4) Дай оценку соответствия от 0 до 100 и короткое обоснование (1–3 предложения).
+
+ОТВЕТ ВЕРНИ СТРОГО В ФОРМАТЕ JSON БЕЗ ОБЪЯСНЕНИЙ И ТЕКСТА ВОКРУГ. ПРИМЕР:
+{
+  "score": 75,
+  "matched": ["требование 1"],
+  "missing": ["требование 2"],
+  "justification": "короткое обоснование"
+}
+
+Вакансия:
+Title: %s
+Requirements:
+%s
+Responsibilities:
+%s
+Nice to have:
+%s
+
+Резюме:
+Position: %s
+Summary: %s
+Skills: %s
+Experience: %s
+Salary: %d
+`,
+		vacancy.Title,
+		bulletList(vacancy.Requirements),
+		bulletList(vacancy.Responsibilities),
+		bulletList(vacancy.NiceToHave),
+		resume.Position, resume.Summary, resume.Skills, resume.Experience, resume.Salary,
+	)
+
+	var result MatchResult
+	err := CompleteJSON(ctx, provider, CompletionRequest{
+		Task:     TaskMatchResume,
+		Messages: []Message{{Role: RoleUser, Content: prompt}},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Score < 0 {
+		result.Score = 0
+	}
+	if result.Score > 100 {
+		result.Score = 100
+	}
+
+	return &result, nil
+}
+
+// extractResult — поля резюме, которые модель нашла в документе. Зарплату
+// модели нередко возвращают строкой («150 000 руб.»), поэтому она разбирается отдельно.
+type extractResult struct {
+	Position   string          `json:"position"`
+	Summary    string          `json:"summary"`
+	Skills     string          `json:"skills"`
+	Experience string          `json:"experience"`
+	Portfolio  string          `json:"portfolio"`
+	Salary     json.RawMessage `json:"salary"`
+}
+
+// ExtractResume раскладывает текст загруженного резюме по полям
+// ResumeCreateRequest. Результат — черновик, его подтверждает соискатель.
+func ExtractResume(ctx context.Context, provider LLMProvider, text string) (*models.ResumeCreateRequest, error) {
+	if runes := []rune(text); len(runes) > maxImportText {
+		text = string(runes[:maxImportText])
+	}
+
+	prompt := fmt.Sprintf(`
+Ты — помощник, который переносит резюме из документа в анкету.
+
+1) Найди в тексте поля: position (желаемая должность), summary (о себе),
+   skills (навыки через запятую), experience (опыт работы: компании, должности, периоды, обязанности),
+   portfolio (ссылки на портфолио, GitHub и т.п.), salary (желаемая зарплата в рублях в месяц, числом).
+2) Ничего не придумывай: если поля нет в тексте, верни пустую строку, а для salary — 0.
+3) Сохраняй формулировки кандидата, исправляя только очевидные артефакты распознавания.
+
+ОТВЕТ ВЕРНИ СТРОГО В ФОРМАТЕ JSON БЕЗ ОБЪЯСНЕНИЙ И ТЕКСТА ВОКРУГ. ПРИМЕР:
+{
+  "position": "должность",
+  "summary": "о себе",
+  "skills": "навык 1, навык 2",
+  "experience": "опыт",
+  "portfolio": "ссылки",
+  "salary": 150000
+}
+
+Текст документа:
+
+%s
+`, text)
+
+	var result extractResult
+	err := CompleteJSON(ctx, provider, CompletionRequest{
+		Task:     TaskExtractResume,
+		Messages: []Message{{Role: RoleUser, Content: prompt}},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ResumeCreateRequest{
+		Position:   strings.TrimSpace(result.Position),
+		Summary:    strings.TrimSpace(result.Summary),
+		Skills:     strings.TrimSpace(result.Skills),
+		Experience: strings.TrimSpace(result.Experience),
+		Portfolio:  strings.TrimSpace(result.Portfolio),
+		Salary:     parseSalary(result.Salary),
+	}, nil
+}
+
+// parseSalary принимает и число, и строку вида "150 000 руб.".
+// Из вилки "150 000 – 200 000" берётся нижняя граница.
+func parseSalary(raw json.RawMessage) int {
+	var number float64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return int(number)
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return 0
+	}
+	var digits strings.Builder
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case digits.Len() > 0 && (r == ' ' || r == '\u00a0'):
+		case digits.Len() > 0:
+			salary, _ := strconv.Atoi(digits.String())
+			return salary
+		}
+	}
+	salary, _ := strconv.Atoi(digits.String())
+	return salary
+}
+
+func bulletList(items []string) string {
+	if len(items) == 0 {
+		return "- нет"
+	}
+	return "- " + strings.Join(items, "\n- ")
+}
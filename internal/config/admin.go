@@ -0,0 +1,19 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+)
+
+// AdminCredentials возвращает ADMIN_EMAIL и ADMIN_PASSWORD — учётные
+// данные администратора, который создаётся при запуске. Без них
+// администратор не создаётся, а уже созданные остаются.
+func AdminCredentials(logger *slog.Logger) (string, string) {
+	email := os.Getenv("ADMIN_EMAIL")
+	password := os.Getenv("ADMIN_PASSWORD")
+	if email == "" || password == "" {
+		logger.Warn("ADMIN_EMAIL or ADMIN_PASSWORD is not set, admin account is not created")
+		return "", ""
+	}
+	return email, password
+}
@@ -0,0 +1,14 @@
+package config
+
+import (
+	"os"
+
+	"github.com/AliUmarov/team-find-me-job/internal/export"
+)
+
+// NewExportRenderer настраивает выгрузку резюме. По умолчанию PDF
+// набирается встроенным DejaVu Sans; PDF_FONT и PDF_FONT_BOLD подменяют
+// его своим TrueType-шрифтом с кириллицей.
+func NewExportRenderer() *export.Renderer {
+	return export.NewRenderer(os.Getenv("PDF_FONT"), os.Getenv("PDF_FONT_BOLD"))
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+)
+
+// JWTSecret возвращает JWT_SECRET — ключ подписи токенов. Без него сервер
+// не запускается: ключ по умолчанию позволил бы подписать токен
+// с любой ролью, включая ADMIN.
+func JWTSecret(logger *slog.Logger) string {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		logger.Error("JWT_SECRET is not set")
+		os.Exit(1)
+	}
+	return secret
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/AliUmarov/team-find-me-job/internal/ai"
+	"github.com/AliUmarov/team-find-me-job/internal/gigachat"
+)
+
+// NewLLMProvider выбирает языковую модель по LLM_PROVIDER:
+// gigachat (по умолчанию), openai — любой OpenAI-совместимый сервер
+// (OPENAI_BASE_URL, OPENAI_API_KEY, OPENAI_MODEL), fake — детерминированная
+// заглушка без сети.
+func NewLLMProvider(logger *slog.Logger) (ai.LLMProvider, error) {
+	provider := strings.ToLower(os.Getenv("LLM_PROVIDER"))
+
+	switch provider {
+	case "", "gigachat":
+		tokenProvider, err := gigachat.NewTokenProvider()
+		if err != nil {
+			return nil, fmt.Errorf("failed to init GigaChat TokenProvider: %w", err)
+		}
+
+		client, err := gigachat.NewClient(tokenProvider, os.Getenv("GIGACHAT_MODEL"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to init GigaChat client: %w", err)
+		}
+
+		logger.Info("llm provider selected", slog.String("provider", "gigachat"))
+		return client, nil
+	case "openai":
+		baseURL := os.Getenv("OPENAI_BASE_URL")
+		if baseURL == "" {
+			baseURL = "https://api.openai.com/v1"
+		}
+
+		logger.Info("llm provider selected",
+			slog.String("provider", "openai"),
+			slog.String("base_url", baseURL),
+		)
+		return ai.NewOpenAIProvider(baseURL, os.Getenv("OPENAI_API_KEY"), os.Getenv("OPENAI_MODEL")), nil
+	case "fake":
+		logger.Warn("llm provider selected", slog.String("provider", "fake"))
+		return ai.NewFake(), nil
+	}
+
+	return nil, fmt.Errorf("unknown LLM_PROVIDER %q", provider)
+}
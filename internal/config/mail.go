@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AliUmarov/team-find-me-job/internal/mail"
+	"gopkg.in/gomail.v2"
+)
+
+const defaultMaildir = "./tmp/mail"
+
+// NewMailTransport выбирает доставку писем по MAIL_TRANSPORT:
+// smtp (SMTP_HOST, SMTP_PORT, SMTP_AUTH_EMAIL, SMTP_AUTH_PASSWORD,
+// SMTP_SENDER_NAME), maildir — файлы в MAIL_DIR (по умолчанию ./tmp/mail),
+// memory — письма остаются в памяти процесса. Без MAIL_TRANSPORT
+// используется smtp, если задан SMTP_HOST, иначе maildir.
+func NewMailTransport(logger *slog.Logger) (mail.Transport, error) {
+	transport := strings.ToLower(os.Getenv("MAIL_TRANSPORT"))
+	if transport == "" {
+		transport = "maildir"
+		if os.Getenv("SMTP_HOST") != "" {
+			transport = "smtp"
+		}
+	}
+
+	from := os.Getenv("SMTP_AUTH_EMAIL")
+	if name := os.Getenv("SMTP_SENDER_NAME"); name != "" && from != "" {
+		from = gomail.NewMessage().FormatAddress(from, name)
+	}
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	switch transport {
+	case "smtp":
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
+		}
+
+		logger.Info("mail transport selected",
+			slog.String("transport", "smtp"),
+			slog.String("host", os.Getenv("SMTP_HOST")),
+		)
+		return mail.NewSMTPTransport(mail.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_AUTH_EMAIL"),
+			Password: os.Getenv("SMTP_AUTH_PASSWORD"),
+			From:     from,
+		}), nil
+	case "maildir":
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = defaultMaildir
+		}
+
+		logger.Info("mail transport selected",
+			slog.String("transport", "maildir"),
+			slog.String("dir", dir),
+		)
+		return mail.NewMaildirTransport(dir, from)
+	case "memory":
+		logger.Info("mail transport selected", slog.String("transport", "memory"))
+		return mail.NewMemoryTransport(), nil
+	}
+
+	return nil, fmt.Errorf("unknown MAIL_TRANSPORT %q", transport)
+}
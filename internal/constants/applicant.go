@@ -14,17 +14,28 @@ const (
 	MESSAGE_SUCCESS_REFRESH_TOKEN       = "success refresh token"
 	MESSAGE_FAILED_LOGOUT               = "failed logout"
 	MESSAGE_SUCCESS_LOGOUT              = "success logout"
+	MESSAGE_FAILED_GET_SESSIONS         = "failed get sessions"
+	MESSAGE_SUCCESS_GET_SESSIONS        = "success get sessions"
+	MESSAGE_FAILED_REVOKE_SESSION       = "failed revoke session"
+	MESSAGE_SUCCESS_REVOKE_SESSION      = "success revoke session"
 	MESSAGE_FAILED_SEND_PASSWORD_RESET  = "failed send password reset"
 	MESSAGE_SUCCESS_SEND_PASSWORD_RESET = "success send password reset"
 	MESSAGE_FAILED_RESET_PASSWORD       = "failed reset password"
 	MESSAGE_SUCCESS_RESET_PASSWORD      = "success reset password"
+	MESSAGE_FAILED_ACCEPT_INVITATION    = "failed accept invitation"
+	MESSAGE_SUCCESS_ACCEPT_INVITATION   = "success accept invitation"
 )
 
 var (
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	ErrRefreshTokenExpired  = errors.New("refresh token expired")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected, session revoked")
+	ErrSessionNotFound      = errors.New("session not found")
 	ErrInvalidCredentials   = errors.New("invalid credentials")
 	ErrPasswordResetToken   = errors.New("password reset token invalid")
+	ErrForbidden            = errors.New("access denied")
+	ErrAccountBlocked       = errors.New("account is blocked")
+	ErrImpersonated         = errors.New("action is not allowed while impersonating")
 )
 
 const (
@@ -0,0 +1,7 @@
+package constants
+
+import "errors"
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid application status transition")
+)
@@ -0,0 +1,8 @@
+package constants
+
+import "errors"
+
+var (
+	ErrUnsupportedImage = errors.New("image must be PNG, JPEG or WebP")
+	ErrImageTooLarge    = errors.New("image is too large")
+)
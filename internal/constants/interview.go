@@ -0,0 +1,16 @@
+package constants
+
+import "errors"
+
+var (
+	ErrInvalidInterviewSlot = errors.New("interview slot must start in the future and last from 15 minutes to 8 hours")
+	ErrSlotOverlaps         = errors.New("interview slot overlaps another slot of the company")
+	ErrSlotNotFound         = errors.New("interview slot not found")
+	ErrSlotUnavailable      = errors.New("interview slot is already booked or has passed")
+	ErrSlotBooked           = errors.New("interview slot is booked; cancel the interview first")
+	ErrInterviewConflict    = errors.New("interview overlaps another scheduled interview")
+	ErrInterviewExists      = errors.New("interview is already scheduled for this application")
+	ErrInterviewNotFound    = errors.New("no scheduled interview for this application")
+	ErrInterviewNotAllowed  = errors.New("interview can be booked only for a reviewed application")
+	ErrInterviewStarted     = errors.New("interview has already started")
+)
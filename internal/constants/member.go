@@ -0,0 +1,10 @@
+package constants
+
+import "errors"
+
+var (
+	ErrMemberExists       = errors.New("email already belongs to a company member")
+	ErrMemberNotFound     = errors.New("company member not found")
+	ErrMemberNotRecruiter = errors.New("only recruiters can be assigned to vacancies")
+	ErrMemberNotActive    = errors.New("company member has not accepted the invitation")
+)
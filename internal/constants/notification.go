@@ -0,0 +1,8 @@
+package constants
+
+import "errors"
+
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrUnknownNotificationType = errors.New("unknown notification type")
+)
@@ -1,5 +1,7 @@
 package constants
 
+import "errors"
+
 const (
 	ERR_CAN_NOT_CREATE_RESUME = "cannot create resume"
 	ERR_CAN_NOT_GET_RESUME    = "cannot get resume"
@@ -7,4 +9,9 @@ const (
 	ERR_CAN_NOT_DELETE_RESUME = "cannot delete resume"
 	ERR_INVALID_JSON          = "invalid JSON"
 	ERR_INVALID_ID            = "invalid id"
+	ERR_INVALID_VERSION       = "invalid version"
+	ERR_INVALID_FILE          = "file is required in multipart field \"file\""
+	ERR_FILE_TOO_LARGE        = "file is too large: 5 MB max"
 )
+
+var ErrNoAISuggestion = errors.New("resume has no AI suggestion yet")
@@ -0,0 +1,9 @@
+package constants
+
+import "errors"
+
+var (
+	ErrReviewNotAllowed = errors.New("only applicants who applied to the company can review it")
+	ErrReviewExists     = errors.New("review for this company already exists")
+	ErrReviewNotFound   = errors.New("review not found")
+)
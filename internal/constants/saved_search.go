@@ -0,0 +1,8 @@
+package constants
+
+import "errors"
+
+var (
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	ErrSavedSearchLimit    = errors.New("saved search limit reached")
+)
@@ -0,0 +1,13 @@
+package constants
+
+import "errors"
+
+var (
+	ErrInvalidCursor            = errors.New("invalid cursor")
+	ErrInvalidVacancyTransition = errors.New("invalid vacancy status transition")
+	ErrVacancyNotEditable       = errors.New("closed or archived vacancy cannot be edited")
+	ErrVacancyNotDraft          = errors.New("only draft vacancies can be deleted")
+	ErrVacancyExpired           = errors.New("vacancy expiry date has passed")
+	ErrVacancyNotPublished      = errors.New("vacancy is not open for applications")
+	ErrInvalidSalaryRange       = errors.New("salary_min must not exceed salary_max")
+)
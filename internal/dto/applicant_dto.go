@@ -64,6 +64,7 @@ type (
 		Email    string `json:"email" binding:"required,email"`
 		Phone    string `json:"phone" binding:"required"`
 		Password string `json:"password" binding:"required,min=8"`
+		Locale   string `json:"locale" binding:"omitempty,oneof=ru en"`
 	}
 
 	ApplicantResponse struct {
@@ -73,6 +74,7 @@ type (
 		Phone      string `json:"phone" binding:"required"`
 		Role       string `json:"role"`
 		IsVerified bool   `json:"is_verified"`
+		Locale     string `json:"locale"`
 	}
 
 	ApplicantUpdateRequest struct {
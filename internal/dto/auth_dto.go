@@ -11,6 +11,13 @@ type (
 		Role         string `json:"role"`
 	}
 
+	// ClientInfo — сведения об устройстве, с которого выполнен вход.
+	ClientInfo struct {
+		Device    string
+		UserAgent string
+		IP        string
+	}
+
 	SendPasswordResetRequest struct {
 		Email string `json:"email" binding:"required,email"`
 	}
@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/AliUmarov/team-find-me-job/internal/models"
+
+type (
+	CompanyResponse struct {
+		models.Base
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Website     string `json:"website"`
+		Email       string `json:"email"`
+		Role        string `json:"role"`
+	}
+
+	CompanyLoginRequest struct {
+		Email    string `json:"email" form:"email" binding:"required"`
+		Password string `json:"password" form:"password" binding:"required"`
+	}
+
+	AcceptInvitationRequest struct {
+		Token    string `json:"token" binding:"required"`
+		Name     string `json:"name" binding:"required,max=100"`
+		Password string `json:"password" binding:"required,min=8"`
+	}
+)
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+)
+
+// Типы событий. Совпадают с полем event в потоке SSE.
+const (
+	TypeApplicationCreated       = "application.created"
+	TypeApplicationStatusChanged = "application.status_changed"
+	TypeAIJobFinished            = "ai_job.finished"
+	TypeMessageCreated           = "message.created"
+	TypeMessagesRead             = "message.read"
+)
+
+// Recipient — субъект, которому адресовано событие.
+type Recipient struct {
+	UserID uint
+	Role   string
+}
+
+// Event — событие для клиента. Data должен сериализоваться в JSON:
+// брокер на другом транспорте передаёт его между процессами.
+type Event struct {
+	ID   uint64
+	Type string
+	Data any
+}
+
+// Broker рассылает события подписчикам. Доставка не гарантируется:
+// события не хранятся, и после переподключения клиент перечитывает
+// состояние через обычные эндпоинты.
+type Broker interface {
+	// Publish отправляет событие всем подключениям получателей.
+	// Ошибки доставки брокер логирует сам.
+	Publish(ctx context.Context, event Event, to ...Recipient)
+	// Subscribe возвращает канал событий получателя. Канал закрывается
+	// при отмене ctx, при остановке брокера и если подписчик не успевает
+	// читать события.
+	Subscribe(ctx context.Context, to Recipient) <-chan Event
+	// Close закрывает все подписки.
+	Close()
+}
+
+type ApplicationPayload struct {
+	ApplicationID uint                     `json:"application_id"`
+	VacancyID     uint                     `json:"vacancy_id"`
+	ResumeID      uint                     `json:"resume_id"`
+	Status        models.ApplicationStatus `json:"status"`
+	FromStatus    models.ApplicationStatus `json:"from_status,omitempty"`
+	ChangedByRole string                   `json:"changed_by_role,omitempty"`
+	MatchScore    *int                     `json:"match_score,omitempty"`
+}
+
+func NewApplicationPayload(application *models.Application) ApplicationPayload {
+	return ApplicationPayload{
+		ApplicationID: application.ID,
+		VacancyID:     application.VacancyID,
+		ResumeID:      application.ResumeID,
+		Status:        application.Status,
+		MatchScore:    application.MatchScore,
+	}
+}
+
+type AIJobPayload struct {
+	JobID    uint               `json:"job_id"`
+	Type     string             `json:"type"`
+	Status   models.AIJobStatus `json:"status"`
+	ResumeID uint               `json:"resume_id"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// MessagesReadPayload — уведомление о прочтении: сторона ReaderRole
+// прочитала все сообщения другой стороны в переписке по отклику.
+type MessagesReadPayload struct {
+	ApplicationID uint      `json:"application_id"`
+	ReaderRole    string    `json:"reader_role"`
+	ReadAt        time.Time `json:"read_at"`
+	Count         int64     `json:"count"`
+}
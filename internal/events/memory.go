@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// subscriberBuffer — сколько событий ждёт в очереди подписчика,
+// прежде чем он будет отключён как отстающий.
+const subscriberBuffer = 32
+
+// memoryBroker — брокер в памяти процесса. Подходит, пока сервис
+// запущен в одном экземпляре.
+type memoryBroker struct {
+	logger *slog.Logger
+
+	mu          sync.Mutex
+	lastID      uint64
+	closed      bool
+	subscribers map[Recipient]map[chan Event]struct{}
+}
+
+func NewMemoryBroker(logger *slog.Logger) Broker {
+	return &memoryBroker{
+		logger:      logger,
+		subscribers: map[Recipient]map[chan Event]struct{}{},
+	}
+}
+
+func (b *memoryBroker) Publish(_ context.Context, event Event, to ...Recipient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+
+	for _, recipient := range to {
+		for ch := range b.subscribers[recipient] {
+			select {
+			case ch <- event:
+			default:
+				// Отстающий подписчик отключается: клиент переподключится
+				// и перечитает состояние, а не получит поток с пропусками.
+				b.logger.Warn("подписчик не успевает читать события и отключён",
+					slog.Uint64("user_id", uint64(recipient.UserID)),
+					slog.String("role", recipient.Role),
+				)
+				b.remove(recipient, ch)
+			}
+		}
+	}
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context, to Recipient) <-chan Event {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch
+	}
+
+	if b.subscribers[to] == nil {
+		b.subscribers[to] = map[chan Event]struct{}{}
+	}
+	b.subscribers[to][ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(to, ch)
+	}()
+
+	return ch
+}
+
+func (b *memoryBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for recipient, channels := range b.subscribers {
+		for ch := range channels {
+			b.remove(recipient, ch)
+		}
+	}
+}
+
+// remove закрывает канал подписчика, если он ещё не закрыт.
+// Вызывается под b.mu.
+func (b *memoryBroker) remove(to Recipient, ch chan Event) {
+	channels, ok := b.subscribers[to]
+	if !ok {
+		return
+	}
+	if _, ok := channels[ch]; !ok {
+		return
+	}
+
+	delete(channels, ch)
+	close(ch)
+	if len(channels) == 0 {
+		delete(b.subscribers, to)
+	}
+}
@@ -0,0 +1,207 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+)
+
+type Format string
+
+const (
+	FormatPDF      Format = "pdf"
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+)
+
+var (
+	ErrUnknownFormat   = errors.New("unknown export format")
+	ErrUnknownTemplate = errors.New("unknown export template")
+	ErrPDFUnavailable  = errors.New("pdf export is not configured: font not found")
+)
+
+// Document — данные резюме для выгрузки вместе с контактами соискателя.
+type Document struct {
+	FullName string
+	Email    string
+	Phone    string
+
+	Position   string
+	Summary    string
+	Skills     string
+	Experience string
+	Portfolio  string
+	Salary     int
+
+	ExperienceYears float64
+	WorkExperience  []models.WorkExperienceData
+	Education       []models.EducationData
+	SkillList       []models.ResumeSkillData
+	Languages       []models.LanguageData
+}
+
+// NewDocument собирает документ из резюме; контакты заполняет вызывающий.
+func NewDocument(resume *models.Resume) Document {
+	sections := resume.Sections()
+
+	return Document{
+		Position:        resume.Position,
+		Summary:         resume.Summary,
+		Skills:          resume.Skills,
+		Experience:      resume.Experience,
+		Portfolio:       resume.Portfolio,
+		Salary:          resume.Salary,
+		ExperienceYears: resume.ExperienceYears,
+		WorkExperience:  sections.WorkExperience,
+		Education:       sections.Education,
+		SkillList:       sections.SkillList,
+		Languages:       sections.Languages,
+	}
+}
+
+// File — результат выгрузки.
+type File struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+// Renderer выгружает резюме в HTML, Markdown и PDF. Шрифты для PDF
+// читаются при первой выгрузке; без них HTML и Markdown продолжают работать.
+// Пустой путь к шрифту означает встроенный DejaVu Sans.
+type Renderer struct {
+	regularFontPath string
+	boldFontPath    string
+
+	once    sync.Once
+	regular *trueTypeFont
+	bold    *trueTypeFont
+	fontErr error
+}
+
+func NewRenderer(regularFontPath, boldFontPath string) *Renderer {
+	return &Renderer{
+		regularFontPath: regularFontPath,
+		boldFontPath:    boldFontPath,
+	}
+}
+
+func (r *Renderer) Render(doc Document, format Format, templateName string) (*File, error) {
+	tmpl, ok := templates[templateName]
+	if !ok {
+		return nil, ErrUnknownTemplate
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatHTML:
+		if err := tmpl.html.Execute(&buf, doc); err != nil {
+			return nil, err
+		}
+		return &File{Data: buf.Bytes(), ContentType: "text/html; charset=utf-8", Extension: "html"}, nil
+	case FormatMarkdown:
+		if err := tmpl.markdown.Execute(&buf, doc); err != nil {
+			return nil, err
+		}
+		return &File{Data: buf.Bytes(), ContentType: "text/markdown; charset=utf-8", Extension: "md"}, nil
+	case FormatPDF:
+		regular, bold, err := r.fonts()
+		if err != nil {
+			return nil, err
+		}
+		data, err := renderPDF(doc, tmpl.pdf, regular, bold)
+		if err != nil {
+			return nil, err
+		}
+		return &File{Data: data, ContentType: "application/pdf", Extension: "pdf"}, nil
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// fonts загружает шрифты один раз. Жирный шрифт необязателен:
+// без него заголовки набираются обычным.
+func (r *Renderer) fonts() (*trueTypeFont, *trueTypeFont, error) {
+	r.once.Do(func() {
+		regular, err := loadTrueTypeFont(r.regularFontPath, dejaVuSans, "DejaVuSans")
+		if err != nil {
+			r.fontErr = fmt.Errorf("%w: %v", ErrPDFUnavailable, err)
+			return
+		}
+		r.regular, r.bold = regular, regular
+
+		if bold, err := loadTrueTypeFont(r.boldFontPath, dejaVuSansBold, "DejaVuSans-Bold"); err == nil {
+			r.bold = bold
+		}
+	})
+
+	return r.regular, r.bold, r.fontErr
+}
+
+func periodLabel(item models.WorkExperienceData) string {
+	end := "по настоящее время"
+	if item.EndDate != nil {
+		end = item.EndDate.Format("01.2006")
+	}
+	return item.StartDate.Format("01.2006") + " — " + end
+}
+
+func educationYears(item models.EducationData) string {
+	switch {
+	case item.StartYear != nil && item.EndYear != nil:
+		return fmt.Sprintf("%d — %d", *item.StartYear, *item.EndYear)
+	case item.EndYear != nil:
+		return fmt.Sprintf("%d", *item.EndYear)
+	case item.StartYear != nil:
+		return fmt.Sprintf("с %d", *item.StartYear)
+	}
+	return ""
+}
+
+func educationTitle(item models.EducationData) string {
+	parts := make([]string, 0, 2)
+	for _, part := range []string{item.Degree, item.Field} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func skillLabel(item models.ResumeSkillData) string {
+	levels := map[models.SkillLevel]string{
+		models.SkillBeginner:     "начальный",
+		models.SkillIntermediate: "средний",
+		models.SkillAdvanced:     "продвинутый",
+		models.SkillExpert:       "эксперт",
+	}
+	if level, ok := levels[item.Level]; ok {
+		return item.Name + " (" + level + ")"
+	}
+	return item.Name
+}
+
+func languageLabel(item models.LanguageData) string {
+	if item.Proficiency == models.LanguageNative {
+		return item.Name + " — родной"
+	}
+	return item.Name + " — " + string(item.Proficiency)
+}
+
+func contacts(doc Document) string {
+	parts := make([]string, 0, 2)
+	for _, part := range []string{doc.Email, doc.Phone} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func yearsLabel(years float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", years), ".0")
+}
@@ -0,0 +1,250 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfMargin     = 50.0
+	pdfLineFactor = 1.4
+)
+
+// pdfStyle — оформление шаблона в PDF.
+type pdfStyle struct {
+	text   rgb
+	muted  rgb
+	accent rgb
+	// headerBand — шапка с именем на цветной плашке.
+	headerBand bool
+}
+
+var white = rgb{1, 1, 1}
+
+type pdfLayout struct {
+	doc     *pdfDocument
+	page    *pdfPage
+	regular *pdfFont
+	bold    *pdfFont
+	style   pdfStyle
+	y       float64
+}
+
+func renderPDF(doc Document, style pdfStyle, regular, bold *trueTypeFont) ([]byte, error) {
+	pdf := &pdfDocument{}
+	l := &pdfLayout{
+		doc:     pdf,
+		regular: pdf.addFont(regular),
+		bold:    pdf.addFont(bold),
+		style:   style,
+	}
+	l.newPage()
+
+	l.header(doc)
+
+	if doc.Summary != "" {
+		l.heading("О себе")
+		l.paragraph(doc.Summary, l.regular, 10.5, style.text, 0)
+	}
+
+	switch {
+	case len(doc.WorkExperience) > 0:
+		l.heading("Опыт работы")
+		if doc.ExperienceYears > 0 {
+			l.paragraph("Общий стаж (лет): "+yearsLabel(doc.ExperienceYears), l.regular, 9.5, style.muted, 0)
+			l.gap(4)
+		}
+		for _, item := range doc.WorkExperience {
+			l.keepTogether(40)
+			l.paragraph(item.Title+" — "+item.Company, l.bold, 11, style.text, 0)
+			l.paragraph(periodLabel(item), l.regular, 9.5, style.muted, 0)
+			if item.Description != "" {
+				l.paragraph(item.Description, l.regular, 10.5, style.text, 0)
+			}
+			l.gap(6)
+		}
+	case doc.Experience != "":
+		l.heading("Опыт работы")
+		l.paragraph(doc.Experience, l.regular, 10.5, style.text, 0)
+	}
+
+	if len(doc.Education) > 0 {
+		l.heading("Образование")
+		for _, item := range doc.Education {
+			l.keepTogether(30)
+			l.paragraph(item.Institution, l.bold, 11, style.text, 0)
+			if title := educationTitle(item); title != "" {
+				l.paragraph(title, l.regular, 10.5, style.text, 0)
+			}
+			if years := educationYears(item); years != "" {
+				l.paragraph(years, l.regular, 9.5, style.muted, 0)
+			}
+			l.gap(6)
+		}
+	}
+
+	switch {
+	case len(doc.SkillList) > 0:
+		l.heading("Навыки")
+		for _, item := range doc.SkillList {
+			l.bullet(skillLabel(item))
+		}
+	case doc.Skills != "":
+		l.heading("Навыки")
+		l.paragraph(doc.Skills, l.regular, 10.5, style.text, 0)
+	}
+
+	if len(doc.Languages) > 0 {
+		l.heading("Языки")
+		for _, item := range doc.Languages {
+			l.bullet(languageLabel(item))
+		}
+	}
+
+	if doc.Portfolio != "" {
+		l.heading("Портфолио")
+		l.paragraph(doc.Portfolio, l.regular, 10.5, style.text, 0)
+	}
+
+	if doc.Salary > 0 {
+		l.heading("Желаемая зарплата")
+		l.paragraph(fmt.Sprintf("%d руб.", doc.Salary), l.regular, 10.5, style.text, 0)
+	}
+
+	return pdf.bytes()
+}
+
+func (l *pdfLayout) newPage() {
+	l.page = l.doc.addPage()
+	l.y = pageHeight - pdfMargin
+}
+
+// keepTogether переносит блок на новую страницу, если от него
+// на текущей поместилось бы меньше height пунктов.
+func (l *pdfLayout) keepTogether(height float64) {
+	if l.y-height < pdfMargin {
+		l.newPage()
+	}
+}
+
+func (l *pdfLayout) gap(height float64) {
+	l.y -= height
+}
+
+func (l *pdfLayout) header(doc Document) {
+	contentWidth := pageWidth - 2*pdfMargin
+
+	if l.style.headerBand {
+		bandHeight := 96.0
+		l.page.rect(0, pageHeight-bandHeight, pageWidth, bandHeight, l.style.accent)
+		l.y = pageHeight - 36
+		l.line(doc.FullName, l.bold, 22, white, pdfMargin)
+		if doc.Position != "" {
+			l.line(doc.Position, l.regular, 12.5, white, pdfMargin)
+		}
+		l.line(contacts(doc), l.regular, 9.5, white, pdfMargin)
+		l.y = pageHeight - bandHeight - 10
+		return
+	}
+
+	l.paragraph(doc.FullName, l.bold, 22, l.style.text, 0)
+	if doc.Position != "" {
+		l.paragraph(doc.Position, l.regular, 13, l.style.muted, 0)
+	}
+	l.paragraph(contacts(doc), l.regular, 9.5, l.style.muted, 0)
+	l.gap(4)
+	l.page.line(pdfMargin, l.y, pdfMargin+contentWidth, l.y, 1, l.style.text)
+	l.gap(4)
+}
+
+func (l *pdfLayout) heading(title string) {
+	l.gap(10)
+	l.keepTogether(40)
+
+	if !l.style.headerBand {
+		title = strings.ToUpper(title)
+	}
+	l.paragraph(title, l.bold, 12.5, l.style.accent, 0)
+
+	if !l.style.headerBand {
+		l.gap(1)
+		l.page.line(pdfMargin, l.y, pageWidth-pdfMargin, l.y, 0.5, l.style.accent)
+	}
+	l.gap(5)
+}
+
+func (l *pdfLayout) bullet(text string) {
+	size := 10.5
+	l.keepTogether(size * pdfLineFactor)
+	l.page.text(l.regular, size, pdfMargin+2, l.y-size, l.style.accent, "•")
+	l.paragraph(text, l.regular, size, l.style.text, 12)
+}
+
+// line выводит одну строку без переноса, не проверяя конец страницы.
+func (l *pdfLayout) line(text string, font *pdfFont, size float64, color rgb, x float64) {
+	l.page.text(font, size, x, l.y-size, color, text)
+	l.y -= size * pdfLineFactor
+}
+
+// paragraph выводит текст с переносом по словам и по страницам.
+// Переводы строк в тексте сохраняются.
+func (l *pdfLayout) paragraph(text string, font *pdfFont, size float64, color rgb, indent float64) {
+	width := pageWidth - 2*pdfMargin - indent
+
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lines := wrap(font.ttf, strings.TrimSpace(raw), size, width)
+		if len(lines) == 0 {
+			lines = []string{""}
+		}
+		for _, line := range lines {
+			l.keepTogether(size * pdfLineFactor)
+			if line != "" {
+				l.page.text(font, size, pdfMargin+indent, l.y-size, color, line)
+			}
+			l.y -= size * pdfLineFactor
+		}
+	}
+}
+
+// wrap разбивает строку на строки не шире width; слишком длинные слова
+// (например, ссылки) режутся посимвольно.
+func wrap(font *trueTypeFont, text string, size, width float64) []string {
+	var lines []string
+	current := ""
+
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if font.textWidth(candidate, size) <= width {
+			current = candidate
+			continue
+		}
+
+		if current != "" {
+			lines = append(lines, current)
+			current = ""
+		}
+		for font.textWidth(word, size) > width {
+			cut := 0
+			for i := range word {
+				if i > 0 && font.textWidth(word[:i], size) > width {
+					break
+				}
+				cut = i
+			}
+			if cut == 0 {
+				break
+			}
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+		current = word
+	}
+
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,246 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Размер страницы A4 в пунктах.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+type rgb [3]float64
+
+func (c rgb) String() string {
+	return fmt.Sprintf("%.3f %.3f %.3f", c[0], c[1], c[2])
+}
+
+// pdfFont — подмножество TrueType-шрифта, встроенное в документ как Type0/Identity-H.
+// Текст кодируется номерами глифов, поэтому кириллица выводится без
+// дополнительных кодировок.
+type pdfFont struct {
+	ttf      *trueTypeFont
+	resource string
+	used     map[uint16]rune
+}
+
+func (f *pdfFont) encode(text string) string {
+	var b strings.Builder
+	b.WriteByte('<')
+	for _, r := range text {
+		glyph := f.ttf.glyph(r)
+		if _, ok := f.used[glyph]; !ok && r <= 0xFFFF {
+			f.used[glyph] = r
+		}
+		fmt.Fprintf(&b, "%04X", glyph)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+func (p *pdfPage) text(font *pdfFont, size, x, y float64, color rgb, text string) {
+	fmt.Fprintf(&p.content, "BT %s rg /%s %.2f Tf %.2f %.2f Td %s Tj ET\n",
+		color, font.resource, size, x, y, font.encode(text))
+}
+
+func (p *pdfPage) rect(x, y, w, h float64, color rgb) {
+	fmt.Fprintf(&p.content, "%s rg %.2f %.2f %.2f %.2f re f\n", color, x, y, w, h)
+}
+
+func (p *pdfPage) line(x1, y1, x2, y2, width float64, color rgb) {
+	fmt.Fprintf(&p.content, "%s RG %.2f w %.2f %.2f m %.2f %.2f l S\n", color, width, x1, y1, x2, y2)
+}
+
+type pdfDocument struct {
+	fonts []*pdfFont
+	pages []*pdfPage
+}
+
+func (d *pdfDocument) addFont(ttf *trueTypeFont) *pdfFont {
+	for _, font := range d.fonts {
+		if font.ttf == ttf {
+			return font
+		}
+	}
+
+	font := &pdfFont{
+		ttf:      ttf,
+		resource: fmt.Sprintf("F%d", len(d.fonts)+1),
+		used:     map[uint16]rune{},
+	}
+	d.fonts = append(d.fonts, font)
+	return font
+}
+
+func (d *pdfDocument) addPage() *pdfPage {
+	page := &pdfPage{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// bytes собирает PDF: каталог, дерево страниц, шрифты и страницы.
+// Номера объектов раздаются заранее, чтобы ссылки можно было писать сразу.
+func (d *pdfDocument) bytes() ([]byte, error) {
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		fontObjs   = 5
+	)
+
+	firstFont := 3
+	firstPage := firstFont + len(d.fonts)*fontObjs
+	objects := make([][]byte, firstPage+len(d.pages)*2)
+
+	var fontResources strings.Builder
+	for i, font := range d.fonts {
+		num := firstFont + i*fontObjs
+		fmt.Fprintf(&fontResources, "/%s %d 0 R ", font.resource, num)
+
+		fontObjects, err := font.objects(num)
+		if err != nil {
+			return nil, err
+		}
+		copy(objects[num:], fontObjects)
+	}
+
+	kids := make([]string, 0, len(d.pages))
+	for i, page := range d.pages {
+		num := firstPage + i*2
+		kids = append(kids, fmt.Sprintf("%d 0 R", num))
+
+		objects[num] = []byte(fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pagesObj, pageWidth, pageHeight, fontResources.String(), num+1,
+		))
+
+		content, err := stream(page.content.Bytes(), "")
+		if err != nil {
+			return nil, err
+		}
+		objects[num+1] = content
+	}
+
+	objects[catalogObj] = []byte(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	objects[pagesObj] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(objects))
+	for num := 1; num < len(objects); num++ {
+		offsets[num] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", num)
+		out.Write(objects[num])
+		out.WriteString("\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects))
+	for num := 1; num < len(objects); num++ {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offsets[num])
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects), catalogObj, xref)
+
+	return out.Bytes(), nil
+}
+
+// objects возвращает пять объектов шрифта начиная с номера num:
+// Type0, CIDFontType2, FontDescriptor, FontFile2 и ToUnicode.
+func (f *pdfFont) objects(num int) ([][]byte, error) {
+	ttf := f.ttf
+
+	glyphs := make([]int, 0, len(f.used))
+	for glyph := range f.used {
+		glyphs = append(glyphs, int(glyph))
+	}
+	sort.Ints(glyphs)
+
+	var widths strings.Builder
+	for _, glyph := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", glyph, ttf.advance(uint16(glyph)))
+	}
+
+	// Встраиваются только использованные глифы: полный DejaVu Sans
+	// весит больше мегабайта.
+	subset, err := ttf.subset(glyphs)
+	if err != nil {
+		return nil, err
+	}
+	fontFile, err := stream(subset, fmt.Sprintf("/Length1 %d", len(subset)))
+	if err != nil {
+		return nil, err
+	}
+	name := subsetTag(glyphs) + "+" + ttf.name
+
+	toUnicode, err := stream(f.toUnicode(glyphs), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]byte{
+		[]byte(fmt.Sprintf(
+			"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+			name, num+1, num+4,
+		)),
+		[]byte(fmt.Sprintf(
+			"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>",
+			name, num+2, widths.String(),
+		)),
+		[]byte(fmt.Sprintf(
+			"<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			name, ttf.scale(ttf.bbox[0]), ttf.scale(ttf.bbox[1]), ttf.scale(ttf.bbox[2]), ttf.scale(ttf.bbox[3]),
+			ttf.scale(ttf.ascent), ttf.scale(ttf.descent), ttf.scale(ttf.capHeight), num+3,
+		)),
+		fontFile,
+		toUnicode,
+	}, nil
+}
+
+// toUnicode строит CMap, по которому просмотрщики копируют и ищут текст.
+func (f *pdfFont) toUnicode(glyphs []int) []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	for start := 0; start < len(glyphs); start += 100 {
+		end := min(start+100, len(glyphs))
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, glyph := range glyphs[start:end] {
+			fmt.Fprintf(&b, "<%04X> <%04X>\n", glyph, f.used[uint16(glyph)])
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
+
+// stream сжимает data и оформляет её как поток PDF с дополнительными
+// ключами словаря extra.
+func stream(data []byte, extra string) ([]byte, error) {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "<< /Length %d /Filter /FlateDecode %s>>\nstream\n", compressed.Len(), extra)
+	out.Write(compressed.Bytes())
+	out.WriteString("\nendstream")
+	return out.Bytes(), nil
+}
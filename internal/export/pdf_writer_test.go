@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/AliUmarov/team-find-me-job/internal/extract"
+)
+
+func TestRenderPDF(t *testing.T) {
+	renderer := NewRenderer("", "")
+	doc := Document{
+		FullName: "Иван Йорданов",
+		Email:    "ivan@example.com",
+		Position: "Go-разработчик",
+		Summary:  "Пишу бэкенд на Go и PostgreSQL.",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		doc      Document
+		minPages int
+	}{
+		{name: "classic", template: TemplateClassic, doc: doc, minPages: 1},
+		{name: "modern", template: TemplateModern, doc: doc, minPages: 1},
+		{
+			name:     "page break",
+			template: TemplateClassic,
+			doc: func() Document {
+				long := doc
+				long.Summary = strings.Repeat("Длинное описание опыта. ", 600)
+				return long
+			}(),
+			minPages: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := renderer.Render(tt.doc, FormatPDF, tt.template)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			data := file.Data
+
+			checkXref(t, data)
+
+			if !regexp.MustCompile(`/BaseFont /[A-Z]{6}\+DejaVuSans `).Match(data) {
+				t.Error("font is not embedded as a tagged subset")
+			}
+			if len(data) > 200_000 {
+				t.Errorf("PDF size = %d, the font looks embedded in full", len(data))
+			}
+			count := regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`).FindSubmatch(data)
+			if count == nil {
+				t.Fatal("no page tree")
+			}
+			if pages, _ := strconv.Atoi(string(count[1])); pages < tt.minPages {
+				t.Errorf("pages = %d, want at least %d", pages, tt.minPages)
+			}
+
+			// Текст должен читаться обратно через ToUnicode. Заголовки
+			// классического шаблона набраны заглавными.
+			text, err := extract.PDF(data)
+			if err != nil {
+				t.Fatalf("extract.PDF() error = %v", err)
+			}
+			for _, want := range []string{"Иван Йорданов", "Go-разработчик", "О себе"} {
+				if !strings.Contains(strings.ToUpper(text), strings.ToUpper(want)) {
+					t.Errorf("extracted text has no %q", want)
+				}
+			}
+		})
+	}
+}
+
+// checkXref проверяет, что startxref указывает на таблицу xref, а каждая
+// её запись — на начало своего объекта.
+func checkXref(t *testing.T, data []byte) {
+	t.Helper()
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if match == nil {
+		t.Fatal("no startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n0 ")) {
+		t.Fatalf("startxref %d does not point to xref", xref)
+	}
+
+	lines := strings.Split(string(data[xref:]), "\n")
+	size, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	for num := 1; num < size; num++ {
+		offset, err := strconv.Atoi(strings.Fields(lines[2+num])[0])
+		if err != nil {
+			t.Fatalf("xref entry %d: %v", num, err)
+		}
+		if want := fmt.Sprintf("%d 0 obj\n", num); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q", num, data[offset:offset+10])
+		}
+	}
+}
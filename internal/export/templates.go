@@ -0,0 +1,70 @@
+package export
+
+import (
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Встроенные шрифты для PDF с кириллицей, чтобы выгрузка не зависела
+// от шрифтов на сервере. Лицензия — fonts/LICENSE.
+var (
+	//go:embed fonts/DejaVuSans.ttf
+	dejaVuSans []byte
+	//go:embed fonts/DejaVuSans-Bold.ttf
+	dejaVuSansBold []byte
+)
+
+const (
+	TemplateClassic = "classic"
+	TemplateModern  = "modern"
+)
+
+// resumeTemplate — встроенный шаблон резюме: разметка для HTML и Markdown
+// и оформление для PDF.
+type resumeTemplate struct {
+	html     *htmltemplate.Template
+	markdown *texttemplate.Template
+	pdf      pdfStyle
+}
+
+var templateFuncs = map[string]any{
+	"period":         periodLabel,
+	"educationYears": educationYears,
+	"educationTitle": educationTitle,
+	"skill":          skillLabel,
+	"language":       languageLabel,
+	"contacts":       contacts,
+	"years":          yearsLabel,
+}
+
+var templates = map[string]resumeTemplate{
+	TemplateClassic: {
+		html:     htmltemplate.Must(htmltemplate.New("classic.html.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/classic.html.tmpl")),
+		markdown: texttemplate.Must(texttemplate.New("classic.md.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/classic.md.tmpl")),
+		pdf: pdfStyle{
+			text:   rgb{0.1, 0.1, 0.1},
+			muted:  rgb{0.4, 0.4, 0.4},
+			accent: rgb{0.1, 0.1, 0.1},
+		},
+	},
+	TemplateModern: {
+		html:     htmltemplate.Must(htmltemplate.New("modern.html.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/modern.html.tmpl")),
+		markdown: texttemplate.Must(texttemplate.New("modern.md.tmpl").Funcs(templateFuncs).ParseFS(templateFiles, "templates/modern.md.tmpl")),
+		pdf: pdfStyle{
+			text:       rgb{0.13, 0.16, 0.2},
+			muted:      rgb{0.42, 0.47, 0.53},
+			accent:     rgb{0.16, 0.38, 0.75},
+			headerBand: true,
+		},
+	},
+}
+
+// IsTemplate сообщает, есть ли встроенный шаблон с таким именем.
+func IsTemplate(name string) bool {
+	_, ok := templates[name]
+	return ok
+}
@@ -0,0 +1,226 @@
+package export
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// trueTypeFont — минимум сведений о TrueType-шрифте, нужный, чтобы
+// разметить текст и встроить в PDF подмножество шрифта: метрики,
+// ширины глифов, cmap и таблицы с контурами.
+type trueTypeFont struct {
+	name       string
+	tables     map[string][]byte
+	numGlyphs  int
+	unitsPerEm int
+	ascent     int
+	descent    int
+	capHeight  int
+	bbox       [4]int
+	widths     []int
+	glyphs     map[rune]uint16
+}
+
+var errBadFont = errors.New("unsupported TrueType font")
+
+// loadTrueTypeFont читает шрифт из файла path, а при пустом path
+// разбирает встроенный шрифт embedded с именем name.
+func loadTrueTypeFont(path string, embedded []byte, name string) (*trueTypeFont, error) {
+	data := embedded
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	font, err := parseTrueTypeFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	font.name = strings.Map(func(r rune) rune {
+		if r > ' ' && r < 0x7f && !strings.ContainsRune("()<>[]{}/%#", r) {
+			return r
+		}
+		return -1
+	}, name)
+
+	return font, nil
+}
+
+func parseTrueTypeFont(data []byte) (*trueTypeFont, error) {
+	tables, err := readTables(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Контуры нужны в формате glyf: шрифты с CFF (OpenType .otf)
+	// в PDF встраиваются иначе и не поддерживаются.
+	head, hhea, hmtx, cmap := tables["head"], tables["hhea"], tables["hmtx"], tables["cmap"]
+	maxp, loca, glyf := tables["maxp"], tables["loca"], tables["glyf"]
+	if len(head) < 54 || len(hhea) < 36 || hmtx == nil || cmap == nil || len(maxp) < 6 || loca == nil || glyf == nil {
+		return nil, errBadFont
+	}
+
+	font := &trueTypeFont{
+		tables:     tables,
+		numGlyphs:  int(binary.BigEndian.Uint16(maxp[4:])),
+		unitsPerEm: int(binary.BigEndian.Uint16(head[18:])),
+		ascent:     int(int16(binary.BigEndian.Uint16(hhea[4:]))),
+		descent:    int(int16(binary.BigEndian.Uint16(hhea[6:]))),
+	}
+	if font.unitsPerEm == 0 {
+		return nil, errBadFont
+	}
+	for i := range font.bbox {
+		font.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+i*2:])))
+	}
+
+	font.capHeight = font.ascent
+	if os2 := tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		font.capHeight = int(int16(binary.BigEndian.Uint16(os2[88:])))
+	}
+
+	numberOfHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numberOfHMetrics == 0 || len(hmtx) < numberOfHMetrics*4 {
+		return nil, errBadFont
+	}
+	font.widths = make([]int, numberOfHMetrics)
+	for i := range font.widths {
+		font.widths[i] = int(binary.BigEndian.Uint16(hmtx[i*4:]))
+	}
+
+	glyphs, err := parseCmap(cmap)
+	if err != nil {
+		return nil, err
+	}
+	font.glyphs = glyphs
+
+	return font, nil
+}
+
+// readTables разбирает каталог таблиц шрифта.
+func readTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, errBadFont
+	}
+
+	tables := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		record := 12 + i*16
+		if record+16 > len(data) {
+			return nil, errBadFont
+		}
+		tag := string(data[record : record+4])
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset+length > len(data) {
+			return nil, errBadFont
+		}
+		tables[tag] = data[offset : offset+length]
+	}
+
+	return tables, nil
+}
+
+// parseCmap читает юникодную подтаблицу cmap формата 4 (BMP).
+func parseCmap(cmap []byte) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errBadFont
+	}
+
+	var subtable []byte
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numTables; i++ {
+		record := 4 + i*8
+		if record+8 > len(cmap) {
+			return nil, errBadFont
+		}
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if offset+4 > len(cmap) || binary.BigEndian.Uint16(cmap[offset:]) != 4 {
+			continue
+		}
+		if (platform == 3 && encoding == 1) || platform == 0 {
+			subtable = cmap[offset:]
+			break
+		}
+	}
+	if len(subtable) < 14 {
+		return nil, errBadFont
+	}
+
+	segCount := int(binary.BigEndian.Uint16(subtable[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	idDeltas := startCodes + segCount*2
+	idRangeOffsets := idDeltas + segCount*2
+	if idRangeOffsets+segCount*2 > len(subtable) {
+		return nil, errBadFont
+	}
+
+	glyphs := map[rune]uint16{}
+	for i := 0; i < segCount; i++ {
+		end := int(binary.BigEndian.Uint16(subtable[endCodes+i*2:]))
+		start := int(binary.BigEndian.Uint16(subtable[startCodes+i*2:]))
+		delta := binary.BigEndian.Uint16(subtable[idDeltas+i*2:])
+		rangeOffsetPos := idRangeOffsets + i*2
+		rangeOffset := int(binary.BigEndian.Uint16(subtable[rangeOffsetPos:]))
+
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			var glyph uint16
+			if rangeOffset == 0 {
+				glyph = uint16(c) + delta
+			} else {
+				addr := rangeOffsetPos + rangeOffset + (c-start)*2
+				if addr+2 > len(subtable) {
+					continue
+				}
+				glyph = binary.BigEndian.Uint16(subtable[addr:])
+				if glyph != 0 {
+					glyph += delta
+				}
+			}
+			if glyph != 0 {
+				glyphs[rune(c)] = glyph
+			}
+		}
+	}
+
+	return glyphs, nil
+}
+
+// glyph возвращает глиф символа; для отсутствующих — глиф 0 (.notdef).
+func (f *trueTypeFont) glyph(r rune) uint16 {
+	return f.glyphs[r]
+}
+
+// advance — ширина глифа в тысячных долях кегля, как её ждёт PDF.
+func (f *trueTypeFont) advance(glyph uint16) int {
+	width := f.widths[len(f.widths)-1]
+	if int(glyph) < len(f.widths) {
+		width = f.widths[glyph]
+	}
+	return f.scale(width)
+}
+
+func (f *trueTypeFont) scale(value int) int {
+	return value * 1000 / f.unitsPerEm
+}
+
+// textWidth — ширина строки в пунктах при кегле size.
+func (f *trueTypeFont) textWidth(text string, size float64) float64 {
+	total := 0
+	for _, r := range text {
+		total += f.advance(f.glyph(r))
+	}
+	return float64(total) * size / 1000
+}
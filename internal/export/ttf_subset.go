@@ -0,0 +1,224 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+)
+
+// subsetTables — таблицы, которые остаются во встроенном шрифте. PDF
+// берёт метрики из словарей шрифта, а символы — через CIDToGIDMap,
+// поэтому cmap, name, post и таблицы OpenType-раскладки не нужны.
+var subsetTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+// Флаги компонента составного глифа.
+const (
+	argsAreWords   = 0x0001
+	haveScale      = 0x0008
+	moreComponents = 0x0020
+	haveXYScale    = 0x0040
+	haveTwoByTwo   = 0x0080
+)
+
+// Поля таблицы head: checkSumAdjustment и indexToLocFormat.
+const (
+	headChecksumPos = 8
+	headLocFormat   = 50
+	checksumMagic   = 0xB1B0AFBA
+)
+
+// subset собирает шрифт, в котором остались только глифы glyphs, глиф 0
+// (.notdef) и части составных глифов. Номера глифов не меняются: лишние
+// глифы становятся пустыми, и CIDToGIDMap /Identity остаётся верной.
+func (f *trueTypeFont) subset(glyphs []int) ([]byte, error) {
+	offsets, err := f.glyphOffsets()
+	if err != nil {
+		return nil, err
+	}
+	glyf := f.tables["glyf"]
+
+	keep := make([]bool, f.numGlyphs)
+	queue := append([]int{0}, glyphs...)
+	for len(queue) > 0 {
+		glyph := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if glyph < 0 || glyph >= f.numGlyphs || keep[glyph] {
+			continue
+		}
+		keep[glyph] = true
+		queue = append(queue, glyphComponents(glyf[offsets[glyph]:offsets[glyph+1]])...)
+	}
+
+	// Новая loca всегда в длинном формате: так не нужно следить,
+	// помещаются ли смещения в 16 бит.
+	var newGlyf bytes.Buffer
+	newLoca := make([]byte, (f.numGlyphs+1)*4)
+	for glyph := 0; glyph < f.numGlyphs; glyph++ {
+		binary.BigEndian.PutUint32(newLoca[glyph*4:], uint32(newGlyf.Len()))
+		if keep[glyph] {
+			newGlyf.Write(glyf[offsets[glyph]:offsets[glyph+1]])
+			newGlyf.Write(make([]byte, padding(newGlyf.Len())))
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[f.numGlyphs*4:], uint32(newGlyf.Len()))
+
+	head := bytes.Clone(f.tables["head"])
+	binary.BigEndian.PutUint32(head[headChecksumPos:], 0)
+	binary.BigEndian.PutUint16(head[headLocFormat:], 1)
+
+	tables := map[string][]byte{}
+	for _, tag := range subsetTables {
+		if table, ok := f.tables[tag]; ok {
+			tables[tag] = table
+		}
+	}
+	tables["glyf"] = newGlyf.Bytes()
+	tables["loca"] = newLoca
+	tables["head"] = head
+
+	return writeTrueTypeFont(tables), nil
+}
+
+// glyphOffsets читает loca: границы глифа i — offsets[i] и offsets[i+1].
+func (f *trueTypeFont) glyphOffsets() ([]int, error) {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	long := binary.BigEndian.Uint16(f.tables["head"][headLocFormat:]) == 1
+
+	size := 2
+	if long {
+		size = 4
+	}
+	if len(loca) < (f.numGlyphs+1)*size {
+		return nil, errBadFont
+	}
+
+	offsets := make([]int, f.numGlyphs+1)
+	for i := range offsets {
+		if long {
+			offsets[i] = int(binary.BigEndian.Uint32(loca[i*4:]))
+		} else {
+			offsets[i] = int(binary.BigEndian.Uint16(loca[i*2:])) * 2
+		}
+		if offsets[i] > len(glyf) || i > 0 && offsets[i] < offsets[i-1] {
+			return nil, errBadFont
+		}
+	}
+
+	return offsets, nil
+}
+
+// glyphComponents возвращает глифы, из которых собран составной глиф
+// (например, «й» из «и» и бреве). У простого глифа их нет.
+func glyphComponents(data []byte) []int {
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+
+	var components []int
+	for pos := 10; pos+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[pos:])
+		components = append(components, int(binary.BigEndian.Uint16(data[pos+2:])))
+
+		pos += 4 + 2
+		if flags&argsAreWords != 0 {
+			pos += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			pos += 2
+		case flags&haveXYScale != 0:
+			pos += 4
+		case flags&haveTwoByTwo != 0:
+			pos += 8
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+
+	return components
+}
+
+// writeTrueTypeFont собирает файл шрифта из таблиц и проставляет
+// контрольные суммы, включая checkSumAdjustment в head.
+func writeTrueTypeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	var out bytes.Buffer
+	header := make([]byte, 12+numTables*16)
+	binary.BigEndian.PutUint32(header, 0x00010000)
+	binary.BigEndian.PutUint16(header[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(numTables*16-searchRange))
+	out.Write(header)
+
+	headOffset := 0
+	for i, tag := range tags {
+		table := tables[tag]
+		if tag == "head" {
+			headOffset = out.Len()
+		}
+
+		record := header[12+i*16:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], checksum(table))
+		binary.BigEndian.PutUint32(record[8:], uint32(out.Len()))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(table)))
+
+		out.Write(table)
+		out.Write(make([]byte, padding(len(table))))
+	}
+
+	data := out.Bytes()
+	copy(data, header)
+	if _, ok := tables["head"]; ok {
+		binary.BigEndian.PutUint32(data[headOffset+headChecksumPos:], checksumMagic-checksum(data))
+	}
+
+	return data
+}
+
+// checksum — сумма 32-битных слов таблицы, как её считает TrueType.
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+func padding(n int) int {
+	return (4 - n%4) % 4
+}
+
+// subsetTag — шесть заглавных букв перед именем шрифта, которыми PDF
+// помечает подмножество. Зависит только от набора глифов.
+func subsetTag(glyphs []int) string {
+	h := fnv.New32a()
+	for _, glyph := range glyphs {
+		binary.Write(h, binary.BigEndian, uint16(glyph))
+	}
+
+	sum := h.Sum32()
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = byte('A' + sum%26)
+		sum /= 26
+	}
+	return string(tag)
+}
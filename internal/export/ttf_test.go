@@ -0,0 +1,163 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+type cmapSegment struct {
+	start, end uint16
+	delta      int
+	// glyphs заполняется у сегментов с idRangeOffset.
+	glyphs []uint16
+}
+
+// buildCmap собирает cmap с одной подтаблицей формата 4 для (3, 1).
+func buildCmap(segments ...cmapSegment) []byte {
+	segments = append(segments, cmapSegment{start: 0xFFFF, end: 0xFFFF, delta: 1})
+	segCount := len(segments)
+
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	idDeltas := startCodes + segCount*2
+	idRangeOffsets := idDeltas + segCount*2
+	glyphIds := idRangeOffsets + segCount*2
+
+	sub := make([]byte, glyphIds)
+	binary.BigEndian.PutUint16(sub, 4)
+	binary.BigEndian.PutUint16(sub[6:], uint16(segCount*2))
+	for i, seg := range segments {
+		binary.BigEndian.PutUint16(sub[endCodes+i*2:], seg.end)
+		binary.BigEndian.PutUint16(sub[startCodes+i*2:], seg.start)
+		binary.BigEndian.PutUint16(sub[idDeltas+i*2:], uint16(seg.delta))
+		if seg.glyphs != nil {
+			rangeOffset := len(sub) - (idRangeOffsets + i*2)
+			binary.BigEndian.PutUint16(sub[idRangeOffsets+i*2:], uint16(rangeOffset))
+			for _, glyph := range seg.glyphs {
+				sub = binary.BigEndian.AppendUint16(sub, glyph)
+			}
+		}
+	}
+	binary.BigEndian.PutUint16(sub[2:], uint16(len(sub)))
+
+	cmap := []byte{0, 0, 0, 1, 0, 3, 0, 1, 0, 0, 0, 12}
+	return append(cmap, sub...)
+}
+
+func TestParseCmap(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmap    []byte
+		want    map[rune]uint16
+		wantErr error
+	}{
+		{
+			name: "delta segment",
+			cmap: buildCmap(cmapSegment{start: 'A', end: 'C', delta: 10 - 'A'}),
+			want: map[rune]uint16{'A': 10, 'B': 11, 'C': 12},
+		},
+		{
+			name: "glyph id array",
+			cmap: buildCmap(cmapSegment{start: 'А', end: 'В', glyphs: []uint16{20, 0, 22}}),
+			want: map[rune]uint16{'А': 20, 'В': 22},
+		},
+		{
+			name: "both segments",
+			cmap: buildCmap(
+				cmapSegment{start: '0', end: '1', delta: 5 - '0'},
+				cmapSegment{start: 'я', end: 'я', delta: 100, glyphs: []uint16{1}},
+			),
+			want: map[rune]uint16{'0': 5, '1': 6, 'я': 101},
+		},
+		{
+			name:    "truncated",
+			cmap:    buildCmap(cmapSegment{start: 'A', end: 'C'})[:20],
+			wantErr: errBadFont,
+		},
+		{
+			name:    "no format 4 subtable",
+			cmap:    []byte{0, 0, 0, 1, 0, 3, 0, 1, 0, 0, 0, 12, 0, 6, 0, 0},
+			wantErr: errBadFont,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCmap(tt.cmap)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseCmap() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("parseCmap() = %v, want %v", got, tt.want)
+			}
+			for r, glyph := range tt.want {
+				if got[r] != glyph {
+					t.Errorf("glyph(%q) = %d, want %d", r, got[r], glyph)
+				}
+			}
+		})
+	}
+}
+
+func TestSubset(t *testing.T) {
+	font, err := loadTrueTypeFont("", dejaVuSans, "DejaVuSans")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var glyphs []int
+	for _, r := range "Привет, й" {
+		glyphs = append(glyphs, int(font.glyph(r)))
+	}
+
+	data, err := font.subset(glyphs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > len(dejaVuSans)/10 {
+		t.Errorf("subset size = %d, want well under %d", len(data), len(dejaVuSans))
+	}
+	if sum := checksum(data); sum != checksumMagic {
+		t.Errorf("font checksum = %#x, want %#x", sum, checksumMagic)
+	}
+
+	tables, err := readTables(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tables["cmap"]; ok {
+		t.Error("subset keeps cmap")
+	}
+	subset := &trueTypeFont{tables: tables, numGlyphs: font.numGlyphs}
+	offsets, err := subset.glyphOffsets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, err := font.glyphOffsets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	glyphData := func(f *trueTypeFont, offsets []int, glyph int) []byte {
+		return f.tables["glyf"][offsets[glyph]:offsets[glyph+1]]
+	}
+
+	// «й» в DejaVu — составной глиф: его части тоже должны остаться.
+	short := int(font.glyph('й'))
+	components := glyphComponents(glyphData(font, original, short))
+	if len(components) == 0 {
+		t.Fatal("й is expected to be a composite glyph")
+	}
+
+	for _, glyph := range append(append([]int{0}, glyphs...), components...) {
+		want := glyphData(font, original, glyph)
+		got := glyphData(subset, offsets, glyph)
+		if !bytes.Equal(bytes.TrimRight(got, "\x00"), bytes.TrimRight(want, "\x00")) {
+			t.Errorf("glyph %d differs from the original", glyph)
+		}
+	}
+	if unused := int(font.glyph('Z')); len(glyphData(subset, offsets, unused)) != 0 {
+		t.Errorf("unused glyph %d is not empty", unused)
+	}
+}
@@ -0,0 +1,148 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// buildDOCX собирает zip-архив с заданными файлами.
+func buildDOCX(files map[string]string) []byte {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range files {
+		f, _ := w.Create(name)
+		f.Write([]byte(content))
+	}
+	w.Close()
+	return b.Bytes()
+}
+
+// documentXML оборачивает тело документа в w:document.
+func documentXML(body string) map[string]string {
+	return map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0"?><Types/>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+			`<w:body>` + body + `</w:body></w:document>`,
+	}
+}
+
+func TestDOCX(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{
+			name: "paragraphs",
+			data: buildDOCX(documentXML(
+				`<w:p><w:r><w:t>Иван</w:t></w:r><w:r><w:t xml:space="preserve"> Петров</w:t></w:r></w:p>` +
+					`<w:p><w:r><w:t>Go-разработчик</w:t></w:r></w:p>`,
+			)),
+			want: "Иван Петров\nGo-разработчик\n",
+		},
+		{
+			name: "tabs and breaks",
+			data: buildDOCX(documentXML(
+				`<w:p><w:r><w:t>Навыки:</w:t><w:tab/><w:t>Go</w:t><w:br/><w:t>SQL</w:t><w:cr/><w:t>Docker</w:t></w:r></w:p>`,
+			)),
+			want: "Навыки:\tGo\nSQL\nDocker\n",
+		},
+		{
+			name: "table",
+			data: buildDOCX(documentXML(
+				`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>2020</w:t></w:r></w:p></w:tc>` +
+					`<w:tc><w:p><w:r><w:t>Acme</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`,
+			)),
+			want: "2020\nAcme\n",
+		},
+		{
+			name: "field codes are skipped",
+			data: buildDOCX(documentXML(
+				`<w:p><w:r><w:instrText> HYPERLINK "https://example.com" </w:instrText></w:r>` +
+					`<w:r><w:t>example.com</w:t></w:r></w:p>`,
+			)),
+			want: "example.com\n",
+		},
+		{
+			name: "escaped characters",
+			data: buildDOCX(documentXML(`<w:p><w:r><w:t>R&amp;D &lt;team&gt;</w:t></w:r></w:p>`)),
+			want: "R&D <team>\n",
+		},
+		{
+			name:    "not a zip",
+			data:    []byte("PK but not really a zip"),
+			wantErr: ErrUnsupportedFormat,
+		},
+		{
+			name:    "no document.xml",
+			data:    buildDOCX(map[string]string{"xl/workbook.xml": "<workbook/>"}),
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DOCX(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DOCX() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DOCX() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextDOCX(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "normalized",
+			filename: "resume.DOCX",
+			data: buildDOCX(documentXML(
+				`<w:p><w:r><w:t xml:space="preserve">  Иван   Петров </w:t></w:r></w:p>` +
+					`<w:p/><w:p/><w:p><w:r><w:t>Go</w:t></w:r></w:p>`,
+			)),
+			want: "Иван Петров\n\nGo",
+		},
+		{
+			name:     "empty document",
+			filename: "resume.docx",
+			data:     buildDOCX(documentXML(`<w:p/>`)),
+			wantErr:  ErrNoText,
+		},
+		{
+			name:     "broken xml",
+			filename: "resume.docx",
+			data:     buildDOCX(map[string]string{"word/document.xml": `<w:document><w:body><w:p>`}),
+			wantErr:  ErrUnreadable,
+		},
+		{
+			name:     "wrong extension",
+			filename: "resume.doc",
+			data:     buildDOCX(documentXML(`<w:p><w:r><w:t>Go</w:t></w:r></w:p>`)),
+			wantErr:  ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Text(tt.filename, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Text() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package extract достаёт простой текст из загруженных документов
+// без внешних зависимостей.
+package extract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported document format: only PDF and DOCX are accepted")
+	ErrNoText            = errors.New("document contains no extractable text")
+	ErrUnreadable        = errors.New("document is damaged or cannot be read")
+)
+
+// Text определяет формат по расширению и сигнатуре файла и возвращает
+// его текст с нормализованными пробелами и переводами строк.
+func Text(filename string, data []byte) (string, error) {
+	var (
+		text string
+		err  error
+	)
+
+	switch ext := strings.ToLower(filepath.Ext(filename)); {
+	case ext == ".pdf" && bytes.HasPrefix(data, []byte("%PDF-")):
+		text, err = PDF(data)
+	case ext == ".docx" && bytes.HasPrefix(data, []byte("PK")):
+		text, err = DOCX(data)
+	default:
+		return "", ErrUnsupportedFormat
+	}
+	if errors.Is(err, ErrUnsupportedFormat) || errors.Is(err, ErrEncrypted) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnreadable, err)
+	}
+
+	text = normalize(text)
+	if text == "" {
+		return "", ErrNoText
+	}
+
+	return text, nil
+}
+
+// normalize схлопывает пробелы внутри строк и лишние пустые строки.
+func normalize(text string) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	blank := false
+
+	for _, line := range lines {
+		line = strings.Join(strings.FieldsFunc(line, unicode.IsSpace), " ")
+		if line == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
@@ -0,0 +1,361 @@
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxStreamSize ограничивает распакованный поток PDF.
+const maxStreamSize = 32 << 20
+
+var ErrEncrypted = errors.New("encrypted PDF documents are not supported")
+
+var objectHeader = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+
+type pdfObject struct {
+	value  any
+	stream []byte // сырые (не распакованные) данные потока
+}
+
+// pdfReader читает объекты PDF прямым проходом по файлу, без xref:
+// так разбираются и файлы с повреждённой таблицей ссылок. Объекты из
+// потоков объектов (/ObjStm, PDF 1.5+) подмешиваются после прохода.
+type pdfReader struct {
+	objects map[int]*pdfObject
+	fonts   map[int]*pdfFont
+}
+
+// PDF возвращает текст страниц по порядку. Текст шрифтов с ToUnicode
+// декодируется по карте, остальных — как WinAnsi. Файл приходит от
+// пользователя, поэтому паника разбора возвращается как ошибка.
+func PDF(data []byte) (text string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			text, err = "", fmt.Errorf("malformed PDF: %v", recovered)
+		}
+	}()
+
+	return readPDF(data)
+}
+
+func readPDF(data []byte) (string, error) {
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return "", ErrEncrypted
+	}
+
+	r := &pdfReader{objects: map[int]*pdfObject{}, fonts: map[int]*pdfFont{}}
+	r.scan(data)
+	r.expandObjectStreams()
+
+	var b strings.Builder
+	for _, page := range r.pages() {
+		fonts := r.pageFonts(page)
+		for _, content := range r.pageContents(page) {
+			b.WriteString(showText(content, fonts))
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String(), nil
+}
+
+func (r *pdfReader) scan(data []byte) {
+	matches := objectHeader.FindAllSubmatchIndex(data, -1)
+	for i, m := range matches {
+		num, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+
+		end := len(data)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		body := data[m[1]:end]
+
+		lexer := &pdfLexer{data: body}
+		value, ok := lexer.next()
+		if !ok {
+			continue
+		}
+		object := &pdfObject{value: value}
+
+		if dict, isDict := value.(pdfDict); isDict {
+			lexer.skipSpace()
+			if bytes.HasPrefix(body[lexer.pos:], []byte("stream")) {
+				object.stream = streamData(body[lexer.pos+len("stream"):], dict)
+			}
+		}
+
+		// При инкрементальных обновлениях действует последнее определение.
+		r.objects[num] = object
+	}
+}
+
+// streamData отрезает данные потока: по /Length, если он задан числом
+// и похож на правду, иначе — до ближайшего endstream.
+func streamData(rest []byte, dict pdfDict) []byte {
+	if bytes.HasPrefix(rest, []byte("\r\n")) {
+		rest = rest[2:]
+	} else if len(rest) > 0 && (rest[0] == '\n' || rest[0] == '\r') {
+		rest = rest[1:]
+	}
+
+	if length, ok := dict["Length"].(float64); ok {
+		n := int(length)
+		if n >= 0 && n <= len(rest) && bytes.HasPrefix(bytes.TrimLeft(rest[n:], "\r\n "), []byte("endstream")) {
+			return rest[:n]
+		}
+	}
+
+	if end := bytes.Index(rest, []byte("endstream")); end >= 0 {
+		return bytes.TrimRight(rest[:end], "\r\n")
+	}
+	return rest
+}
+
+func (r *pdfReader) expandObjectStreams() {
+	for _, object := range r.objectsSnapshot() {
+		dict, ok := object.value.(pdfDict)
+		if !ok || dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		data, err := r.decode(object)
+		if err != nil {
+			continue
+		}
+
+		count, _ := r.resolve(dict["N"]).(float64)
+		first, _ := r.resolve(dict["First"]).(float64)
+		if first < 0 || first > float64(len(data)) {
+			continue
+		}
+
+		header := &pdfLexer{data: data[:int(first)]}
+		for i := 0; i < int(count); i++ {
+			num, ok1 := header.next()
+			offset, ok2 := header.next()
+			n, isNum := num.(float64)
+			off, isOff := offset.(float64)
+			if !ok1 || !ok2 || !isNum || !isOff || off < 0 || off > float64(len(data)) {
+				break
+			}
+			if _, exists := r.objects[int(n)]; exists {
+				continue
+			}
+			start := int(first) + int(off)
+			if start < int(first) || start >= len(data) {
+				continue
+			}
+			value, ok := (&pdfLexer{data: data[start:]}).next()
+			if ok {
+				r.objects[int(n)] = &pdfObject{value: value}
+			}
+		}
+	}
+}
+
+func (r *pdfReader) objectsSnapshot() []*pdfObject {
+	objects := make([]*pdfObject, 0, len(r.objects))
+	for _, object := range r.objects {
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+func (r *pdfReader) resolve(value any) any {
+	for i := 0; i < 32; i++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		object, ok := r.objects[int(ref)]
+		if !ok {
+			return nil
+		}
+		value = object.value
+	}
+	return nil
+}
+
+func (r *pdfReader) dict(value any) pdfDict {
+	dict, _ := r.resolve(value).(pdfDict)
+	return dict
+}
+
+// decode распаковывает поток. Поддерживается только FlateDecode —
+// им сжато подавляющее большинство текстовых потоков.
+func (r *pdfReader) decode(object *pdfObject) ([]byte, error) {
+	dict, _ := object.value.(pdfDict)
+
+	var filters []pdfName
+	switch filter := r.resolve(dict["Filter"]).(type) {
+	case pdfName:
+		filters = append(filters, filter)
+	case pdfArray:
+		for _, f := range filter {
+			if name, ok := r.resolve(f).(pdfName); ok {
+				filters = append(filters, name)
+			}
+		}
+	}
+
+	data := object.stream
+	for _, filter := range filters {
+		if filter != "FlateDecode" && filter != "Fl" {
+			return nil, errors.New("unsupported PDF filter " + string(filter))
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		// Повреждённый хвост потока не мешает прочитать начало.
+		decoded, err := io.ReadAll(io.LimitReader(zr, maxStreamSize))
+		if len(decoded) == 0 && err != nil {
+			return nil, err
+		}
+		data = decoded
+	}
+
+	return data, nil
+}
+
+func (r *pdfReader) pages() []pdfDict {
+	var pages []pdfDict
+	seen := map[int]bool{}
+
+	var walk func(value any, depth int)
+	walk = func(value any, depth int) {
+		if ref, ok := value.(pdfRef); ok {
+			if seen[int(ref)] {
+				return
+			}
+			seen[int(ref)] = true
+		}
+		node := r.dict(value)
+		if node == nil || depth > 64 {
+			return
+		}
+		switch node["Type"] {
+		case pdfName("Page"):
+			pages = append(pages, node)
+		default:
+			kids, _ := r.resolve(node["Kids"]).(pdfArray)
+			for _, kid := range kids {
+				walk(kid, depth+1)
+			}
+		}
+	}
+
+	for _, num := range r.sortedNumbers() {
+		if dict, ok := r.objects[num].value.(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+			walk(dict["Pages"], 0)
+			if len(pages) > 0 {
+				return pages
+			}
+		}
+	}
+
+	// Дерево страниц не нашлось — берём страницы в порядке номеров объектов.
+	for _, num := range r.sortedNumbers() {
+		if dict, ok := r.objects[num].value.(pdfDict); ok && dict["Type"] == pdfName("Page") {
+			pages = append(pages, dict)
+		}
+	}
+	return pages
+}
+
+func (r *pdfReader) sortedNumbers() []int {
+	numbers := make([]int, 0, len(r.objects))
+	for num := range r.objects {
+		numbers = append(numbers, num)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+func (r *pdfReader) pageContents(page pdfDict) [][]byte {
+	var refs []any
+	switch contents := page["Contents"].(type) {
+	case pdfArray:
+		refs = contents
+	default:
+		if array, ok := r.resolve(contents).(pdfArray); ok {
+			refs = array
+		} else {
+			refs = []any{contents}
+		}
+	}
+
+	var out [][]byte
+	for _, ref := range refs {
+		num, ok := ref.(pdfRef)
+		if !ok {
+			continue
+		}
+		object, ok := r.objects[int(num)]
+		if !ok || object.stream == nil {
+			continue
+		}
+		if data, err := r.decode(object); err == nil {
+			out = append(out, data)
+		}
+	}
+	return out
+}
+
+// pageFonts возвращает шрифты страницы по именам ресурсов
+// с учётом наследования /Resources от родительских узлов.
+func (r *pdfReader) pageFonts(page pdfDict) map[string]*pdfFont {
+	node := page
+	for depth := 0; node != nil && depth < 64; depth++ {
+		if resources := r.dict(node["Resources"]); resources != nil {
+			fonts := map[string]*pdfFont{}
+			for name, ref := range r.dict(resources["Font"]) {
+				fonts[name] = r.font(ref)
+			}
+			return fonts
+		}
+		node = r.dict(node["Parent"])
+	}
+	return nil
+}
+
+func (r *pdfReader) font(ref any) *pdfFont {
+	num, isRef := ref.(pdfRef)
+	if isRef {
+		if font, ok := r.fonts[int(num)]; ok {
+			return font
+		}
+	}
+
+	dict := r.dict(ref)
+	font := &pdfFont{codeLength: 1}
+	if dict["Subtype"] == pdfName("Type0") {
+		font.codeLength = 2
+	}
+
+	if toUnicode, ok := dict["ToUnicode"].(pdfRef); ok {
+		if object, ok := r.objects[int(toUnicode)]; ok && object.stream != nil {
+			if data, err := r.decode(object); err == nil {
+				font.cmap = parseCMap(data)
+				if font.cmap.codeLength > 0 {
+					font.codeLength = font.cmap.codeLength
+				}
+			}
+		}
+	}
+
+	if isRef {
+		r.fonts[int(num)] = font
+	}
+	return font
+}
@@ -0,0 +1,298 @@
+package extract
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Значения PDF-объектов. Строки и имена храним отдельными типами,
+// ссылки на объекты — как pdfRef, операторы потоков — как pdfOp.
+type (
+	pdfDict  map[string]any
+	pdfArray []any
+	pdfName  string
+	pdfOp    string
+	pdfRef   int
+	pdfStr   []byte
+)
+
+// maxPDFNesting ограничивает вложенность словарей и массивов: без
+// ограничения файл из одних «[» переполнил бы стек.
+const maxPDFNesting = 256
+
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func isPDFSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0:
+		return true
+	}
+	return false
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// next читает следующее значение; ok == false в конце данных.
+// Закрывающие скобки возвращаются как pdfOp, чтобы их видели
+// разборщики словарей и массивов.
+func (l *pdfLexer) next() (any, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '<' && l.peek(1) == '<':
+		l.pos += 2
+		return l.dict(), true
+	case c == '>' && l.peek(1) == '>':
+		l.pos += 2
+		return pdfOp(">>"), true
+	case c == '<':
+		return l.hexString(), true
+	case c == '[':
+		l.pos++
+		return l.array(), true
+	case c == ']':
+		l.pos++
+		return pdfOp("]"), true
+	case c == '(':
+		return l.literalString(), true
+	case c == '/':
+		return l.name(), true
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.number(), true
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		l.pos++
+	}
+	return pdfOp(l.data[start:l.pos]), true
+}
+
+func (l *pdfLexer) peek(offset int) byte {
+	if l.pos+offset < len(l.data) {
+		return l.data[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *pdfLexer) dict() pdfDict {
+	dict := pdfDict{}
+	if l.enter() {
+		return dict
+	}
+	defer l.leave()
+
+	for {
+		key, ok := l.next()
+		if !ok || key == pdfOp(">>") {
+			return dict
+		}
+		name, isName := key.(pdfName)
+		if !isName {
+			continue
+		}
+		value, ok := l.next()
+		if !ok || value == pdfOp(">>") {
+			return dict
+		}
+		dict[string(name)] = value
+	}
+}
+
+func (l *pdfLexer) array() pdfArray {
+	var array pdfArray
+	if l.enter() {
+		return array
+	}
+	defer l.leave()
+
+	for {
+		value, ok := l.next()
+		if !ok || value == pdfOp("]") {
+			return array
+		}
+		array = append(array, value)
+	}
+}
+
+// enter увеличивает вложенность и сообщает, превышен ли предел.
+// Тогда остаток данных пропускается.
+func (l *pdfLexer) enter() bool {
+	if l.depth >= maxPDFNesting {
+		l.pos = len(l.data)
+		return true
+	}
+	l.depth++
+	return false
+}
+
+func (l *pdfLexer) leave() {
+	l.depth--
+}
+
+// number читает число, а если за ним идут «G R» — ссылку на объект.
+func (l *pdfLexer) number() any {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.data) && (l.data[l.pos] == '.' || (l.data[l.pos] >= '0' && l.data[l.pos] <= '9')) {
+		l.pos++
+	}
+	token := string(l.data[start:l.pos])
+
+	if n, err := strconv.Atoi(token); err == nil {
+		save := l.pos
+		l.skipSpace()
+		genStart := l.pos
+		for l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '9' {
+			l.pos++
+		}
+		if l.pos > genStart {
+			l.skipSpace()
+			if l.peek(0) == 'R' && (l.pos+1 >= len(l.data) || isPDFSpace(l.peek(1)) || isPDFDelimiter(l.peek(1))) {
+				l.pos++
+				return pdfRef(n)
+			}
+		}
+		l.pos = save
+		return float64(n)
+	}
+
+	f, _ := strconv.ParseFloat(token, 64)
+	return f
+}
+
+func (l *pdfLexer) name() pdfName {
+	l.pos++
+	var b bytes.Buffer
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b.WriteByte(c)
+		l.pos++
+	}
+	return pdfName(b.String())
+}
+
+func (l *pdfLexer) hexString() pdfStr {
+	l.pos++
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		c := l.data[l.pos]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	if l.pos < len(l.data) {
+		l.pos++
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, _ := strconv.ParseUint(string(digits[i*2:i*2+2]), 16, 8)
+		out[i] = byte(v)
+	}
+	return out
+}
+
+func (l *pdfLexer) literalString() pdfStr {
+	l.pos++
+	var out []byte
+	depth := 1
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.peek(0) == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		out = append(out, c)
+	}
+
+	return out
+}
@@ -0,0 +1,209 @@
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF собирает PDF из тел объектов 1..n. Таблица xref не нужна:
+// читатель находит объекты прямым проходом.
+func buildPDF(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+	for i, object := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+func stream(dict string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func deflate(data string) []byte {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write([]byte(data))
+	w.Close()
+	return b.Bytes()
+}
+
+// objectStream собирает /ObjStm с заданными заголовком и телами объектов.
+func objectStream(first string, header string, body string) string {
+	data := header + body
+	return stream("/Type /ObjStm /N 1 /First "+first+" /Filter /FlateDecode", deflate(data))
+}
+
+const helloContent = "BT /F1 12 Tf 72 720 Td (Hello, world) Tj ET"
+
+func helloPDF() []byte {
+	return buildPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		stream("", []byte(helloContent)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	)
+}
+
+func TestPDF(t *testing.T) {
+	toUnicode := "/CIDInit /ProcSet findresource begin begincmap\n" +
+		"1 begincodespacerange <0000> <FFFF> endcodespacerange\n" +
+		"2 beginbfchar <0001> <041F> <0002> <0440> endbfchar\n" +
+		"1 beginbfrange <0003> <0004> <0438> endbfrange\n" +
+		"endcmap"
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "plain text", data: helloPDF(), want: "Hello, world"},
+		{
+			name: "compressed content",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+				stream("/Filter /FlateDecode", deflate("BT (Compressed) Tj ET")),
+			),
+			want: "Compressed",
+		},
+		{
+			name: "to unicode cmap",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+				stream("", []byte("BT /F1 12 Tf <0001000200030004> Tj ET")),
+				"<< /Type /Font /Subtype /Type0 /ToUnicode 6 0 R >>",
+				stream("", []byte(toUnicode)),
+			),
+			want: "Прий",
+		},
+		{
+			name: "page in object stream",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [5 0 R] /Count 1 >>",
+				stream("", []byte("BT (From ObjStm) Tj ET")),
+				objectStream("4", "5 0 ", "<< /Type /Page /Parent 2 0 R /Contents 3 0 R >>"),
+			),
+			want: "From ObjStm",
+		},
+		{
+			name: "negative first",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [] /Count 0 >>",
+				objectStream("-3", "5 0 ", "<< /Type /Page >>"),
+			),
+		},
+		{
+			name: "negative offset",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [] /Count 0 >>",
+				objectStream("7", "5 -50 ", "<< /Type /Page >>"),
+			),
+		},
+		{
+			name: "huge first",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [] /Count 0 >>",
+				objectStream("1e300", "5 0 ", "<< /Type /Page >>"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := PDF(tt.data)
+			if err != nil {
+				t.Fatalf("PDF() error = %v", err)
+			}
+			if got := normalize(text); got != tt.want {
+				t.Errorf("PDF() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPDFEncrypted(t *testing.T) {
+	data := buildPDF("<< /Type /Catalog >>", "<< /Filter /Standard >>")
+	data = bytes.Replace(data, []byte("/Root 1 0 R"), []byte("/Root 1 0 R /Encrypt 2 0 R"), 1)
+
+	if _, err := PDF(data); err != ErrEncrypted {
+		t.Errorf("PDF() error = %v, want %v", err, ErrEncrypted)
+	}
+}
+
+func TestParseCMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		codeLength int
+		codes      map[uint32]string
+	}{
+		{
+			name:       "bfchar",
+			data:       "1 begincodespacerange <00> <FF> endcodespacerange 1 beginbfchar <41> <0042> endbfchar",
+			codeLength: 1,
+			codes:      map[uint32]string{0x41: "B"},
+		},
+		{
+			name:       "bfrange with start",
+			data:       "1 begincodespacerange <0000> <FFFF> endcodespacerange 1 beginbfrange <0010> <0012> <0410> endbfrange",
+			codeLength: 2,
+			codes:      map[uint32]string{0x10: "А", 0x11: "Б", 0x12: "В"},
+		},
+		{
+			name:       "bfrange with list",
+			data:       "1 beginbfrange <01> <02> [<0066006C> <0021>] endbfrange",
+			codeLength: 0,
+			codes:      map[uint32]string{0x01: "fl", 0x02: "!"},
+		},
+		{
+			name:       "surrogate pair",
+			data:       "1 beginbfchar <0001> <D83DDE00> endbfchar",
+			codeLength: 0,
+			codes:      map[uint32]string{0x01: "😀"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := parseCMap([]byte(tt.data))
+			if m.codeLength != tt.codeLength {
+				t.Errorf("codeLength = %d, want %d", m.codeLength, tt.codeLength)
+			}
+			for code, want := range tt.codes {
+				if got, _ := m.lookup(code); got != want {
+					t.Errorf("lookup(%#x) = %q, want %q", code, got, want)
+				}
+			}
+		})
+	}
+}
+
+// FuzzPDF проверяет, что разбор произвольных данных не паникует.
+// Вызывается readPDF, а не PDF: recover в PDF скрыл бы панику.
+func FuzzPDF(f *testing.F) {
+	f.Add(helloPDF())
+	f.Add(buildPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [5 0 R] /Count 1 >>",
+		stream("/Filter /FlateDecode", deflate(helloContent)),
+		objectStream("4", "5 0 ", "<< /Type /Page /Parent 2 0 R /Contents 3 0 R >>"),
+	))
+	f.Add([]byte(strings.Repeat("1 0 obj << /Kids [1 0 R] >> endobj ", 4)))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		readPDF(data)
+	})
+}
@@ -0,0 +1,284 @@
+package extract
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+)
+
+// Отрицательный сдвиг в TJ больше этого порога считается пробелом
+// между словами (в тысячных долях кегля).
+const tjSpaceThreshold = -200
+
+type pdfFont struct {
+	codeLength int
+	cmap       *cmap
+}
+
+type cmapRange struct {
+	low, high uint32
+	start     []rune
+	list      []string
+}
+
+type cmap struct {
+	codeLength int
+	chars      map[uint32]string
+	ranges     []cmapRange
+}
+
+// parseCMap разбирает ToUnicode: codespacerange, bfchar и bfrange.
+func parseCMap(data []byte) *cmap {
+	m := &cmap{chars: map[uint32]string{}}
+	lexer := &pdfLexer{data: data}
+	var operands []any
+
+	for {
+		value, ok := lexer.next()
+		if !ok {
+			return m
+		}
+		op, isOp := value.(pdfOp)
+		if !isOp {
+			operands = append(operands, value)
+			continue
+		}
+
+		switch op {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if low, ok := operands[i].(pdfStr); ok && len(low) > m.codeLength {
+					m.codeLength = len(low)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(pdfStr)
+				dst, ok2 := operands[i+1].(pdfStr)
+				if ok1 && ok2 {
+					m.chars[code(src)] = utf16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, ok1 := operands[i].(pdfStr)
+				high, ok2 := operands[i+1].(pdfStr)
+				if !ok1 || !ok2 {
+					continue
+				}
+				r := cmapRange{low: code(low), high: code(high)}
+				switch dst := operands[i+2].(type) {
+				case pdfStr:
+					r.start = []rune(utf16BE(dst))
+				case pdfArray:
+					for _, item := range dst {
+						s, _ := item.(pdfStr)
+						r.list = append(r.list, utf16BE(s))
+					}
+				}
+				m.ranges = append(m.ranges, r)
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+func code(b []byte) uint32 {
+	var c uint32
+	for _, v := range b {
+		c = c<<8 | uint32(v)
+	}
+	return c
+}
+
+func utf16BE(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+func (m *cmap) lookup(c uint32) (string, bool) {
+	if s, ok := m.chars[c]; ok {
+		return s, true
+	}
+	for _, r := range m.ranges {
+		if c < r.low || c > r.high {
+			continue
+		}
+		offset := int(c - r.low)
+		if r.list != nil {
+			if offset < len(r.list) {
+				return r.list[offset], true
+			}
+			return "", false
+		}
+		if len(r.start) == 0 {
+			return "", false
+		}
+		out := append([]rune{}, r.start...)
+		out[len(out)-1] += rune(offset)
+		return string(out), true
+	}
+	return "", false
+}
+
+// decode переводит байты строки показа текста в Unicode.
+func (f *pdfFont) decode(b []byte) string {
+	if f == nil || f.cmap == nil {
+		if f != nil && f.codeLength == 2 {
+			// Составной шрифт без ToUnicode: коды — номера глифов,
+			// восстановить текст нельзя.
+			return ""
+		}
+		return winAnsi(b)
+	}
+
+	var out strings.Builder
+	step := max(f.codeLength, 1)
+	for i := 0; i+step <= len(b); i += step {
+		if s, ok := f.cmap.lookup(code(b[i : i+step])); ok {
+			out.WriteString(s)
+		}
+	}
+	return out.String()
+}
+
+// winAnsiHigh — символы WinAnsiEncoding в диапазоне 0x80–0x9F,
+// остальные коды совпадают с Latin-1.
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+func winAnsi(b []byte) string {
+	var out strings.Builder
+	for _, c := range b {
+		if r, ok := winAnsiHigh[c]; ok {
+			out.WriteRune(r)
+		} else {
+			out.WriteRune(rune(c))
+		}
+	}
+	return out.String()
+}
+
+// showText выполняет текстовые операторы потока содержимого и собирает
+// текст. Переход на новую строку определяется по вертикальным сдвигам.
+func showText(content []byte, fonts map[string]*pdfFont) string {
+	var (
+		out      strings.Builder
+		operands []any
+		font     *pdfFont
+		lastY    float64
+		hasY     bool
+	)
+
+	newline := func() {
+		if out.Len() > 0 {
+			out.WriteByte('\n')
+		}
+	}
+	space := func() {
+		if out.Len() > 0 {
+			out.WriteByte(' ')
+		}
+	}
+	number := func(i int) float64 {
+		if i < 0 || i >= len(operands) {
+			return 0
+		}
+		n, _ := operands[i].(float64)
+		return n
+	}
+
+	lexer := &pdfLexer{data: content}
+	for {
+		value, ok := lexer.next()
+		if !ok {
+			break
+		}
+		op, isOp := value.(pdfOp)
+		if !isOp {
+			operands = append(operands, value)
+			continue
+		}
+
+		switch op {
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[0].(pdfName); ok {
+					font = fonts[string(name)]
+				}
+			}
+		case "Td", "TD":
+			switch {
+			case number(len(operands)-1) != 0:
+				newline()
+			case number(len(operands)-2) != 0:
+				space()
+			}
+		case "T*":
+			newline()
+		case "Tm":
+			y := number(len(operands) - 1)
+			if hasY && y != lastY {
+				newline()
+			} else {
+				space()
+			}
+			lastY, hasY = y, true
+		case "Tj":
+			if s, ok := lastString(operands); ok {
+				out.WriteString(font.decode(s))
+			}
+		case "'", "\"":
+			newline()
+			if s, ok := lastString(operands); ok {
+				out.WriteString(font.decode(s))
+			}
+		case "TJ":
+			if array, ok := lastArray(operands); ok {
+				for _, item := range array {
+					switch v := item.(type) {
+					case pdfStr:
+						out.WriteString(font.decode(v))
+					case float64:
+						if v < tjSpaceThreshold {
+							out.WriteByte(' ')
+						}
+					}
+				}
+			}
+		case "BI":
+			// Встроенное изображение: пропускаем двоичные данные до EI.
+			if end := bytes.Index(content[lexer.pos:], []byte("EI")); end >= 0 {
+				lexer.pos += end + 2
+			} else {
+				lexer.pos = len(content)
+			}
+		}
+		operands = operands[:0]
+	}
+
+	return out.String()
+}
+
+func lastString(operands []any) ([]byte, bool) {
+	if len(operands) == 0 {
+		return nil, false
+	}
+	s, ok := operands[len(operands)-1].(pdfStr)
+	return s, ok
+}
+
+func lastArray(operands []any) (pdfArray, bool) {
+	if len(operands) == 0 {
+		return nil, false
+	}
+	a, ok := operands[len(operands)-1].(pdfArray)
+	return a, ok
+}
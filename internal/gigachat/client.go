@@ -2,37 +2,85 @@ package gigachat
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+
+	"github.com/AliUmarov/team-find-me-job/internal/ai"
 )
 
 const apiURL = "https://gigachat.devices.sberbank.ru/api/v1/chat/completions"
 
+const defaultModel = "GigaChat"
+
 type Client struct {
 	http   *http.Client
 	tokens *TokenProvider
+	model  string
 }
 
-func NewClient(tokens *TokenProvider) (*Client, error) {
+func NewClient(tokens *TokenProvider, model string) (*Client, error) {
 	tlsCfg, err := LoadGigaChatTLS()
 	if err != nil {
 		return nil, err
 	}
 
+	if model == "" {
+		model = defaultModel
+	}
+
 	return &Client{
 		http: &http.Client{
+			Timeout: ai.RequestTimeout,
 			Transport: &http.Transport{
 				TLSClientConfig: tlsCfg,
 			},
 		},
 		tokens: tokens,
+		model:  model,
 	}, nil
 }
 
-func (c *Client) send(payload any) ([]byte, error) {
+type chatRequest struct {
+	Model    string       `json:"model"`
+	Messages []ai.Message `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// Complete реализует ai.LLMProvider. Отдельного JSON-режима у GigaChat нет,
+// поэтому req.JSON обеспечивается только текстом промпта.
+func (c *Client) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+	raw, err := c.send(ctx, chatRequest{
+		Model:    c.model,
+		Messages: req.Messages,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var apiResp chatResponse
+	if err := json.Unmarshal(raw, &apiResp); err != nil {
+		return "", fmt.Errorf("не удалось разобрать ответ GigaChat: %w", err)
+	}
+
+	if len(apiResp.Choices) == 0 {
+		return "", fmt.Errorf("ответ пустой (choices=0)")
+	}
+
+	return apiResp.Choices[0].Message.Content, nil
+}
+
+func (c *Client) send(ctx context.Context, payload any) ([]byte, error) {
 	token, err := c.tokens.GetToken()
 	if err != nil {
 		return nil, err
@@ -40,7 +88,7 @@ func (c *Client) send(payload any) ([]byte, error) {
 
 	body, _ := json.Marshal(payload)
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +109,7 @@ func (c *Client) send(payload any) ([]byte, error) {
 	respBytes, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("ошибка %d: %s", resp.StatusCode, respBytes)
+		return nil, &ai.StatusError{Provider: "gigachat", StatusCode: resp.StatusCode, Body: string(respBytes)}
 	}
 
 	return respBytes, nil
@@ -14,6 +14,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// tokenTimeout ограничивает запрос токена к серверу авторизации.
+const tokenTimeout = 30 * time.Second
+
 type tokenResponse struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int    `json:"expires_in"`
@@ -33,20 +36,17 @@ func NewTokenProvider() (*TokenProvider, error) {
 	}
 
 	client := &http.Client{
+		Timeout: tokenTimeout,
 		Transport: &http.Transport{
 			TLSClientConfig: tlsCfg,
 		},
 	}
 
-	p := &TokenProvider{
+	// Токен запрашивается лениво при первом обращении к модели,
+	// чтобы API поднимался и без доступа к серверу авторизации.
+	return &TokenProvider{
 		client: client,
-	}
-
-	if err := p.refresh(); err != nil {
-		return nil, err
-	}
-
-	return p, nil
+	}, nil
 }
 
 func (p *TokenProvider) refresh() error {
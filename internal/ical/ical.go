@@ -0,0 +1,147 @@
+// Package ical собирает приглашения в формате iCalendar (RFC 5545).
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Method string
+
+const (
+	MethodRequest Method = "REQUEST"
+	MethodCancel  Method = "CANCEL"
+)
+
+// maxLineLength — предел длины строки в октетах; длинные строки
+// переносятся с пробелом в начале продолжения.
+const maxLineLength = 75
+
+const timeFormat = "20060102T150405Z"
+
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Event — встреча в календаре. Обновление встречи отправляется
+// с тем же UID и увеличенным Sequence, отмена — с MethodCancel.
+type Event struct {
+	UID         string
+	Sequence    int
+	Method      Method
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	Summary     string
+	Description string
+	Location    string
+	Organizer   Person
+	Attendees   []Person
+}
+
+// Marshal возвращает календарь с одним событием.
+func (e Event) Marshal() []byte {
+	method := e.Method
+	if method == "" {
+		method = MethodRequest
+	}
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	var b strings.Builder
+	line := func(name, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//find-me-job//interviews//RU")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", string(method))
+	line("BEGIN", "VEVENT")
+	line("UID", e.UID)
+	line("SEQUENCE", fmt.Sprint(e.Sequence))
+	line("DTSTAMP", stamp.UTC().Format(timeFormat))
+	line("DTSTART", e.Start.UTC().Format(timeFormat))
+	line("DTEND", e.End.UTC().Format(timeFormat))
+	line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION", escape(e.Location))
+	}
+	if e.Organizer.Email != "" {
+		writeFolded(&b, "ORGANIZER"+commonName(e.Organizer.Name)+":mailto:"+e.Organizer.Email)
+	}
+	for _, attendee := range e.Attendees {
+		if attendee.Email == "" {
+			continue
+		}
+		writeFolded(&b, "ATTENDEE"+commonName(attendee.Name)+";ROLE=REQ-PARTICIPANT:mailto:"+attendee.Email)
+	}
+	if method == MethodCancel {
+		line("STATUS", "CANCELLED")
+	} else {
+		line("STATUS", "CONFIRMED")
+	}
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// ContentType возвращает MIME-тип вложения с параметром method,
+// по которому почтовые клиенты показывают приглашение.
+func (e Event) ContentType() string {
+	method := e.Method
+	if method == "" {
+		method = MethodRequest
+	}
+	return "text/calendar; charset=utf-8; method=" + string(method)
+}
+
+func commonName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + strings.NewReplacer(`"`, "'", "\r", "", "\n", " ").Replace(name) + `"`
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// writeFolded пишет строку, перенося её по maxLineLength октетов
+// без разрыва UTF-8 символов.
+func writeFolded(b *strings.Builder, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Строка продолжения начинается с пробела, он входит в предел.
+		limit = maxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
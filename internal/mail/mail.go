@@ -0,0 +1,283 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Message — готовое к отправке письмо.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	// Summary — короткий текст для уведомления в приложении; в письмо не входит.
+	Summary     string
+	Attachments []Attachment
+}
+
+// Attachment — файл, приложенный к письму.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+// Transport доставляет письма. Реализации: SMTP, каталог в формате
+// maildir и запись в память для тестов.
+type Transport interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Language string
+
+const (
+	LanguageRU Language = "ru"
+	LanguageEN Language = "en"
+
+	DefaultLanguage = LanguageRU
+)
+
+// ParseLanguage выбирает язык писем по коду или заголовку Accept-Language.
+// Неподдерживаемые языки заменяются языком по умолчанию.
+func ParseLanguage(value string) Language {
+	for _, part := range strings.Split(value, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		switch Language(primary) {
+		case LanguageRU, LanguageEN:
+			return Language(primary)
+		}
+	}
+	return DefaultLanguage
+}
+
+// Шаблоны писем.
+const (
+	TemplateVerifyEmail              = "verify_email"
+	TemplateResetPassword            = "reset_password"
+	TemplateApplicationReceived      = "application_received"
+	TemplateApplicationStatusChanged = "application_status_changed"
+	TemplateApplicationWithdrawn     = "application_withdrawn"
+	TemplateResumeImproved           = "resume_improved"
+	TemplateInterviewScheduled       = "interview_scheduled"
+	TemplateInterviewRescheduled     = "interview_rescheduled"
+	TemplateInterviewCancelled       = "interview_cancelled"
+	TemplateMemberInvitation         = "member_invitation"
+	TemplateSavedSearchDigest        = "saved_search_digest"
+)
+
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+// LinkData — данные писем со ссылкой из одноразового токена.
+type LinkData struct {
+	Name         string
+	Link         string
+	ExpiresHours int
+}
+
+// ApplicationData — данные писем об откликах.
+type ApplicationData struct {
+	RecipientName string
+	ApplicantName string
+	CompanyName   string
+	VacancyTitle  string
+	Status        string
+	Comment       string
+	MatchScore    *int
+}
+
+// ResumeData — данные писем о резюме.
+type ResumeData struct {
+	RecipientName string
+	Position      string
+	Score         int
+}
+
+// InterviewData — данные писем о собеседованиях.
+type InterviewData struct {
+	RecipientName string
+	ApplicantName string
+	CompanyName   string
+	VacancyTitle  string
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Location      string
+	Reason        string
+}
+
+// InvitationData — данные письма с приглашением в команду компании.
+type InvitationData struct {
+	CompanyName  string
+	InviterName  string
+	Role         string
+	Link         string
+	ExpiresHours int
+}
+
+// DigestData — данные подборки новых вакансий по сохранённому поиску.
+// Vacancies содержит первые вакансии подборки, Total — все найденные.
+type DigestData struct {
+	RecipientName string
+	SearchName    string
+	Vacancies     []DigestVacancy
+	Total         int64
+	Link          string
+}
+
+type DigestVacancy struct {
+	Title       string
+	CompanyName string
+	City        string
+	Link        string
+}
+
+var statusLabels = map[Language]map[string]string{
+	LanguageRU: {
+		"pending":   "на рассмотрении",
+		"reviewed":  "просмотрен",
+		"interview": "приглашение на собеседование",
+		"offer":     "предложение о работе",
+		"accepted":  "принят",
+		"rejected":  "отказ",
+		"withdrawn": "отозван",
+	},
+	LanguageEN: {
+		"pending":   "pending",
+		"reviewed":  "reviewed",
+		"interview": "interview",
+		"offer":     "offer",
+		"accepted":  "accepted",
+		"rejected":  "rejected",
+		"withdrawn": "withdrawn",
+	},
+}
+
+func statusLabel(lang Language) func(status string) string {
+	return func(status string) string {
+		if label, ok := statusLabels[lang][status]; ok {
+			return label
+		}
+		return status
+	}
+}
+
+var roleLabels = map[Language]map[string]string{
+	LanguageRU: {
+		"owner":     "владелец",
+		"admin":     "администратор",
+		"recruiter": "рекрутер",
+		"viewer":    "наблюдатель",
+	},
+	LanguageEN: {
+		"owner":     "owner",
+		"admin":     "admin",
+		"recruiter": "recruiter",
+		"viewer":    "viewer",
+	},
+}
+
+func roleLabel(lang Language) func(role string) string {
+	return func(role string) string {
+		if label, ok := roleLabels[lang][role]; ok {
+			return label
+		}
+		return role
+	}
+}
+
+// dateTimeLayouts — формат времени в письмах. Время выводится в UTC:
+// точное локальное время участники видят в приложенном календаре.
+var dateTimeLayouts = map[Language]string{
+	LanguageRU: "02.01.2006 15:04 MST",
+	LanguageEN: "Jan 2, 2006 15:04 MST",
+}
+
+func dateTime(lang Language) func(t time.Time) string {
+	return func(t time.Time) string {
+		return t.UTC().Format(dateTimeLayouts[lang])
+	}
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+type emailTemplate struct {
+	html *htmltemplate.Template
+	// text содержит блок "subject" с темой письма и, для событий,
+	// блок "summary" для уведомления в приложении.
+	text *texttemplate.Template
+}
+
+var templates = loadTemplates()
+
+// loadTemplates разбирает каждый файл отдельно: блоки "subject"
+// в разных шаблонах не должны перекрывать друг друга.
+func loadTemplates() map[string]emailTemplate {
+	out := map[string]emailTemplate{}
+	for _, lang := range []Language{LanguageRU, LanguageEN} {
+		for _, name := range []string{
+			TemplateVerifyEmail,
+			TemplateResetPassword,
+			TemplateApplicationReceived,
+			TemplateApplicationStatusChanged,
+			TemplateApplicationWithdrawn,
+			TemplateResumeImproved,
+			TemplateInterviewScheduled,
+			TemplateInterviewRescheduled,
+			TemplateInterviewCancelled,
+			TemplateMemberInvitation,
+			TemplateSavedSearchDigest,
+		} {
+			base := "templates/" + string(lang) + "/" + name
+			funcs := map[string]any{"status": statusLabel(lang), "role": roleLabel(lang), "datetime": dateTime(lang)}
+			out[string(lang)+"/"+name] = emailTemplate{
+				html: htmltemplate.Must(htmltemplate.New(name+".html").Funcs(funcs).ParseFS(templateFS, base+".html")),
+				text: texttemplate.Must(texttemplate.New(name+".txt").Funcs(funcs).ParseFS(templateFS, base+".txt")),
+			}
+		}
+	}
+	return out
+}
+
+// Render собирает тему и обе версии письма; получателя заполняет вызывающий.
+func Render(name string, lang Language, data any) (Message, error) {
+	tmpl, ok := templates[string(lang)+"/"+name]
+	if !ok {
+		tmpl, ok = templates[string(DefaultLanguage)+"/"+name]
+	}
+	if !ok {
+		return Message{}, ErrUnknownTemplate
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return Message{}, err
+	}
+
+	var summary bytes.Buffer
+	if tmpl.text.Lookup("summary") != nil {
+		if err := tmpl.text.ExecuteTemplate(&summary, "summary", data); err != nil {
+			return Message{}, err
+		}
+	}
+
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+		Summary: strings.TrimSpace(summary.String()),
+	}, nil
+}
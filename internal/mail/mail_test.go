@@ -0,0 +1,29 @@
+package mail
+
+import "testing"
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Language
+	}{
+		{value: "", want: LanguageRU},
+		{value: "ru", want: LanguageRU},
+		{value: "en", want: LanguageEN},
+		{value: "EN", want: LanguageEN},
+		{value: "en-US", want: LanguageEN},
+		{value: "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7", want: LanguageRU},
+		{value: "en-GB, ru;q=0.5", want: LanguageEN},
+		{value: "fr-FR, fr;q=0.9, en;q=0.8", want: LanguageEN},
+		{value: " de ;q=0.9 , ru ;q=0.1", want: LanguageRU},
+		{value: "fr, de", want: LanguageRU},
+		{value: "*", want: LanguageRU},
+		{value: "english", want: LanguageRU},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLanguage(tt.value); got != tt.want {
+			t.Errorf("ParseLanguage(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// newMIMEMessage собирает multipart/alternative письмо с текстовой
+// и HTML-версией и вложениями.
+func newMIMEMessage(from string, msg Message) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetBody("text/plain", msg.Text)
+	if msg.HTML != "" {
+		m.AddAlternative("text/html", msg.HTML)
+	}
+	for _, attachment := range msg.Attachments {
+		m.Attach(attachment.Filename,
+			gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(attachment.Data)
+				return err
+			}),
+		)
+	}
+	return m
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPTransport отправляет письма через SMTP-сервер.
+type SMTPTransport struct {
+	config SMTPConfig
+	dialer *gomail.Dialer
+}
+
+func NewSMTPTransport(config SMTPConfig) *SMTPTransport {
+	return &SMTPTransport{
+		config: config,
+		dialer: gomail.NewDialer(config.Host, config.Port, config.Username, config.Password),
+	}
+}
+
+func (t *SMTPTransport) Send(_ context.Context, msg Message) error {
+	return t.dialer.DialAndSend(newMIMEMessage(t.config.From, msg))
+}
+
+// MaildirTransport складывает письма .eml-файлами в каталог формата
+// maildir (tmp → new). Удобен для локальной разработки: письма можно
+// открыть любым почтовым клиентом.
+type MaildirTransport struct {
+	dir     string
+	from    string
+	counter atomic.Int64
+}
+
+func NewMaildirTransport(dir string, from string) (*MaildirTransport, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	return &MaildirTransport{dir: dir, from: from}, nil
+}
+
+func (t *MaildirTransport) Send(_ context.Context, msg Message) error {
+	host, _ := os.Hostname()
+	name := fmt.Sprintf("%d.%d_%d.%s.eml", time.Now().UnixNano(), os.Getpid(), t.counter.Add(1), host)
+	tmpPath := filepath.Join(t.dir, "tmp", name)
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if _, err := newMIMEMessage(t.from, msg).WriteTo(file); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(t.dir, "new", name))
+}
+
+// MemoryTransport запоминает отправленные письма; для тестов.
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []Message
+	// Err, если задан, возвращается вместо отправки.
+	Err error
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (t *MemoryTransport) Send(_ context.Context, msg Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Err != nil {
+		return t.Err
+	}
+	t.messages = append(t.messages, msg)
+	return nil
+}
+
+// Messages возвращает копию отправленных писем.
+func (t *MemoryTransport) Messages() []Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]Message(nil), t.messages...)
+}
+
+func (t *MemoryTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.messages = nil
+}
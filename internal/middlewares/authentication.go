@@ -1,16 +1,30 @@
 package middlewares
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/dto"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/pkg/utils"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
-func Authenticate(jwtService services.JWTService) gin.HandlerFunc {
+// Accounts сообщает, заблокирован ли субъект администратором, и ведёт
+// журнал изменений, сделанных администратором под чужим именем.
+type Accounts interface {
+	IsBlocked(ctx context.Context, userId uint, role string) (bool, error)
+	RecordImpersonated(ctx context.Context, adminId uint, role string, userId uint, request string)
+}
+
+// Authenticate проверяет access-токен и отклоняет заблокированных
+// пользователей: блокировка действует сразу, не дожидаясь истечения токена.
+// Успешные изменяющие запросы по токену входа под пользователем
+// записываются в журнал администратора.
+func Authenticate(jwtService services.JWTService, accounts Accounts) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 
@@ -47,8 +61,95 @@ func Authenticate(jwtService services.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		role, err := jwtService.GetRoleByToken(authHeader)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		// Токены без роли субъекта (например, выпущенные раньше для писем)
+		// не дают доступа к API.
+		if !models.IsPrincipalRole(role) {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		adminId, err := jwtService.GetImpersonatorByToken(authHeader)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		blocked, err := accounts.IsBlocked(ctx.Request.Context(), userId, role)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+		if blocked {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, constants.ErrAccountBlocked.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+
 		ctx.Set("token", authHeader)
 		ctx.Set("user_id", userId)
+		ctx.Set("role", role)
+		if adminId == 0 {
+			ctx.Next()
+			return
+		}
+
+		ctx.Set("impersonated_by", adminId)
+		ctx.Next()
+
+		audited := isMutation(ctx.Request.Method) || ctx.GetBool("audit_impersonation")
+		if audited && ctx.Writer.Status() < http.StatusBadRequest {
+			request := ctx.Request.Method + " " + ctx.Request.URL.Path
+			accounts.RecordImpersonated(context.WithoutCancel(ctx.Request.Context()), adminId, role, userId, request)
+		}
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// AuditImpersonation помечает маршрут, который меняет данные, хотя
+// вызывается методом GET: такие запросы под пользователем тоже попадают
+// в журнал администратора.
+func AuditImpersonation() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set("audit_impersonation", true)
+		ctx.Next()
+	}
+}
+
+// CurrentImpersonator возвращает ID администратора, если запрос сделан
+// по токену входа под пользователем.
+func CurrentImpersonator(ctx *gin.Context) (uint, bool) {
+	adminId := ctx.GetUint("impersonated_by")
+	return adminId, adminId != 0
+}
+
+// ForbidImpersonation отклоняет запрос, сделанный администратором под
+// чужим именем: удалять аккаунт, менять почту, пароль и сессии может
+// только сам пользователь. Должен идти после Authenticate.
+func ForbidImpersonation() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := CurrentImpersonator(ctx); ok {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, constants.ErrImpersonated.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+
 		ctx.Next()
 	}
 }
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type fakeAccounts struct {
+	recorded []string
+}
+
+func (a *fakeAccounts) IsBlocked(context.Context, uint, string) (bool, error) {
+	return false, nil
+}
+
+func (a *fakeAccounts) RecordImpersonated(_ context.Context, _ uint, _ string, _ uint, request string) {
+	a.recorded = append(a.recorded, request)
+}
+
+func TestAuthenticateImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtService := services.NewJWTService("test")
+	impersonated := jwtService.GenerateImpersonationToken(7, models.RoleCompany, 1)
+	own := jwtService.GenerateAccessToken(7, models.RoleCompany)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	fail := func(c *gin.Context) { c.Status(http.StatusBadRequest) }
+
+	tests := []struct {
+		name         string
+		token        string
+		method       string
+		path         string
+		wantStatus   int
+		wantRecorded bool
+	}{
+		{name: "mutation", token: impersonated, method: http.MethodPatch, path: "/update", wantStatus: http.StatusOK, wantRecorded: true},
+		{name: "failed mutation", token: impersonated, method: http.MethodPatch, path: "/fail", wantStatus: http.StatusBadRequest},
+		{name: "read", token: impersonated, method: http.MethodGet, path: "/read", wantStatus: http.StatusOK},
+		{name: "audited GET", token: impersonated, method: http.MethodGet, path: "/accept", wantStatus: http.StatusOK, wantRecorded: true},
+		{name: "forbidden", token: impersonated, method: http.MethodDelete, path: "/account", wantStatus: http.StatusForbidden},
+		{name: "own token", token: own, method: http.MethodDelete, path: "/account", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := &fakeAccounts{}
+			r := gin.New()
+			r.Use(Authenticate(jwtService, accounts))
+			r.PATCH("/update", ok)
+			r.PATCH("/fail", fail)
+			r.GET("/read", ok)
+			r.GET("/accept", AuditImpersonation(), ok)
+			r.DELETE("/account", ForbidImpersonation(), ok)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if recorded := len(accounts.recorded) > 0; recorded != tt.wantRecorded {
+				t.Errorf("recorded = %v, want %v", accounts.recorded, tt.wantRecorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/dto"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OwnerResolver возвращает ID владельца ресурса по его идентификатору.
+type OwnerResolver func(id uint) (uint, error)
+
+// CurrentUser возвращает ID и роль субъекта, выставленные Authenticate.
+func CurrentUser(ctx *gin.Context) (uint, string) {
+	return ctx.GetUint("user_id"), ctx.GetString("role")
+}
+
+// AbortForbidden завершает запрос ответом 403.
+func AbortForbidden(ctx *gin.Context) {
+	response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_DENIED_ACCESS, nil)
+	ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+}
+
+// Authorize пропускает запрос, только если роль из токена входит в roles.
+// Должен идти после Authenticate.
+func Authorize(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		_, role := CurrentUser(ctx)
+		if !slices.Contains(roles, role) {
+			AbortForbidden(ctx)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireOwner проверяет, что ресурс из параметра пути param принадлежит
+// текущему субъекту с ролью role. Если resolve равен nil, параметр сам
+// является ID субъекта (например, /applicant/:id).
+func RequireOwner(role string, param string, resolve OwnerResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId, userRole := CurrentUser(ctx)
+		if userRole != role {
+			AbortForbidden(ctx)
+			return
+		}
+
+		id, err := strconv.ParseUint(ctx.Param(param), 10, 64)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		ownerId := uint(id)
+		if resolve != nil {
+			ownerId, err = resolve(uint(id))
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+				ctx.AbortWithStatusJSON(http.StatusNotFound, response)
+				return
+			}
+			if err != nil {
+				response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, response)
+				return
+			}
+		}
+
+		if ownerId != userId {
+			AbortForbidden(ctx)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// MemberRoleResolver возвращает роль активного сотрудника компании.
+type MemberRoleResolver func(companyId uint, memberId uint) (models.MemberRole, error)
+
+// RequireCompanyMember пропускает компанию из параметра пути param
+// и её сотрудников с одной из ролей roles. Аккаунт компании действует
+// как владелец. Роль сохраняется в контексте для CurrentCompanyActor.
+func RequireCompanyMember(param string, resolve MemberRoleResolver, roles ...models.MemberRole) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId, userRole := CurrentUser(ctx)
+
+		id, err := strconv.ParseUint(ctx.Param(param), 10, 64)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		var memberRole models.MemberRole
+		switch userRole {
+		case models.RoleCompany:
+			if uint(id) != userId {
+				AbortForbidden(ctx)
+				return
+			}
+			memberRole = models.MemberOwner
+		case models.RoleCompanyMember:
+			memberRole, err = resolve(uint(id), userId)
+			if err != nil {
+				AbortForbidden(ctx)
+				return
+			}
+		default:
+			AbortForbidden(ctx)
+			return
+		}
+
+		if memberRole != models.MemberOwner && !slices.Contains(roles, memberRole) {
+			AbortForbidden(ctx)
+			return
+		}
+
+		ctx.Set("member_role", string(memberRole))
+		ctx.Next()
+	}
+}
+
+// CurrentCompanyActor возвращает того, кто действует от имени компании.
+// Должен идти после RequireCompanyMember.
+func CurrentCompanyActor(ctx *gin.Context) models.CompanyActor {
+	actor := models.CompanyActor{Role: models.MemberRole(ctx.GetString("member_role"))}
+	if userId, role := CurrentUser(ctx); role == models.RoleCompanyMember {
+		actor.MemberID = userId
+	}
+	return actor
+}
@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+// Admin — администратор платформы. Регистрации нет: аккаунт создаётся
+// при запуске из ADMIN_EMAIL и ADMIN_PASSWORD.
+type Admin struct {
+	Base
+
+	Email    string `json:"email" gorm:"type:varchar(255);not null;uniqueIndex"`
+	Name     string `json:"name" gorm:"type:varchar(100);not null;default:''"`
+	Password string `json:"-" gorm:"type:varchar(255);not null"`
+}
+
+// Действия администратора, которые попадают в журнал.
+const (
+	AdminActionBlock           = "block"
+	AdminActionUnblock         = "unblock"
+	AdminActionTakedownVacancy = "takedown_vacancy"
+	AdminActionTakedownReview  = "takedown_review"
+	AdminActionImpersonate     = "impersonate"
+	// AdminActionImpersonated — изменение, сделанное под пользователем;
+	// в Reason записывается метод и путь запроса.
+	AdminActionImpersonated = "impersonated_request"
+)
+
+// AdminAction — запись журнала действий администратора. TargetRole
+// определяет, в какой таблице искать TargetID: роль субъекта для
+// аккаунтов, vacancy или review для контента.
+type AdminAction struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	AdminID    uint      `json:"admin_id" gorm:"not null;index"`
+	Action     string    `json:"action" gorm:"type:varchar(50);not null;index"`
+	TargetRole string    `json:"target_role" gorm:"type:varchar(50);not null"`
+	TargetID   uint      `json:"target_id" gorm:"not null"`
+	Reason     string    `json:"reason" gorm:"type:text;not null;default:''"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// Цели действий над контентом.
+const (
+	AdminTargetVacancy = "vacancy"
+	AdminTargetReview  = "review"
+)
+
+// AccountSummary — соискатель или компания в списке администратора.
+type AccountSummary struct {
+	ID          uint       `json:"id"`
+	Role        string     `json:"role"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	Verified    bool       `json:"verified"`
+	BlockedAt   *time.Time `json:"blocked_at"`
+	BlockReason string     `json:"block_reason"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// AccountFilter: Query ищет по имени и почте без учёта регистра.
+type AccountFilter struct {
+	Query   string `form:"q" binding:"max=255"`
+	Blocked *bool  `form:"blocked"`
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset  int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AccountList struct {
+	Items []AccountSummary `json:"items"`
+	Total int64            `json:"total"`
+}
+
+type AdminActionFilter struct {
+	Action  string `form:"action" binding:"omitempty,max=50"`
+	AdminID uint   `form:"admin_id"`
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset  int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type AdminActionList struct {
+	Items []AdminAction `json:"items"`
+	Total int64         `json:"total"`
+}
+
+// ModerationRequest — причина блокировки или снятия контента.
+type ModerationRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// ImpersonateRequest — вход под пользователем для поддержки.
+type ImpersonateRequest struct {
+	Role   string `json:"role" binding:"required,oneof=APPLICANT COMPANY COMPANY_MEMBER"`
+	UserID uint   `json:"user_id" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// PlatformStats — сводка по платформе. Разбивки по статусам содержат
+// только встречающиеся статусы.
+type PlatformStats struct {
+	Applicants        int64 `json:"applicants"`
+	BlockedApplicants int64 `json:"blocked_applicants"`
+	Companies         int64 `json:"companies"`
+	VerifiedCompanies int64 `json:"verified_companies"`
+	BlockedCompanies  int64 `json:"blocked_companies"`
+	CompanyMembers    int64 `json:"company_members"`
+	// SignupsLastWeek — соискатели и компании, зарегистрированные за 7 дней.
+	SignupsLastWeek int64 `json:"signups_last_week"`
+
+	Vacancies    map[string]int64 `json:"vacancies"`
+	Applications map[string]int64 `json:"applications"`
+	Reviews      map[string]int64 `json:"reviews"`
+}
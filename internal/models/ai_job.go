@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AIJobStatus string
+
+const (
+	AIJobQueued    AIJobStatus = "queued"
+	AIJobRunning   AIJobStatus = "running"
+	AIJobSucceeded AIJobStatus = "succeeded"
+	AIJobFailed    AIJobStatus = "failed"
+)
+
+// Типы фоновых ИИ-задач.
+const (
+	AIJobTypeImproveResume    = "resume_improve"
+	AIJobTypeMatchApplication = "application_match"
+)
+
+// AIJob — фоновая задача для языковой модели. Задачи забирает пул
+// воркеров; при временных ошибках задача возвращается в очередь с RunAt в будущем.
+type AIJob struct {
+	Base
+
+	Type   string      `json:"type" gorm:"type:varchar(50);not null"`
+	Status AIJobStatus `json:"status" gorm:"type:varchar(20);not null;default:'queued';index:idx_ai_jobs_queue,priority:1"`
+
+	ResumeID  uint   `json:"resume_id" gorm:"not null;index"`
+	OwnerID   uint   `json:"owner_id" gorm:"not null"`
+	OwnerRole string `json:"owner_role" gorm:"type:varchar(50);not null"`
+
+	// ApplicationID заполнен у задач, оценивающих отклик.
+	ApplicationID *uint `json:"application_id,omitempty" gorm:"index"`
+
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null;default:5"`
+	RunAt       time.Time  `json:"run_at" gorm:"type:timestamp with time zone;not null;index:idx_ai_jobs_queue,priority:2"`
+	StartedAt   *time.Time `json:"started_at" gorm:"type:timestamp with time zone"`
+	FinishedAt  *time.Time `json:"finished_at" gorm:"type:timestamp with time zone"`
+
+	Result json.RawMessage `json:"result,omitempty" gorm:"type:jsonb"`
+	Error  string          `json:"error,omitempty" gorm:"type:text"`
+}
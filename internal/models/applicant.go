@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -14,6 +16,12 @@ type Applicant struct {
 	Role     string `gorm:"type:varchar(50);not null;default:'APPLICANT'" json:"role"`
 
 	IsVerified bool `gorm:"default:false" json:"is_verified"`
+	// Locale — язык писем: ru или en.
+	Locale string `gorm:"type:varchar(5);not null;default:'ru'" json:"locale"`
+
+	// BlockedAt выставляет администратор; заблокированный не может войти.
+	BlockedAt   *time.Time `gorm:"index" json:"-"`
+	BlockReason string     `gorm:"type:text;not null;default:''" json:"-"`
 }
 
 
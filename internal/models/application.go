@@ -1,14 +1,46 @@
 package models
 
+import "github.com/lib/pq"
+
 type ApplicationStatus string
 
 const (
-	StatusPending  ApplicationStatus = "pending"
-	StatusReviewed ApplicationStatus = "reviewed"
-	StatusAccepted ApplicationStatus = "accepted"
-	StatusRejected ApplicationStatus = "rejected"
+	StatusPending   ApplicationStatus = "pending"
+	StatusReviewed  ApplicationStatus = "reviewed"
+	StatusInterview ApplicationStatus = "interview"
+	StatusOffer     ApplicationStatus = "offer"
+	StatusAccepted  ApplicationStatus = "accepted"
+	StatusRejected  ApplicationStatus = "rejected"
+	StatusWithdrawn ApplicationStatus = "withdrawn"
 )
 
+// applicationTransitions — допустимые переходы статусов отклика.
+// accepted, rejected и withdrawn конечные.
+var applicationTransitions = map[ApplicationStatus][]ApplicationStatus{
+	StatusPending:   {StatusReviewed, StatusRejected, StatusWithdrawn},
+	StatusReviewed:  {StatusInterview, StatusOffer, StatusRejected, StatusWithdrawn},
+	StatusInterview: {StatusOffer, StatusRejected, StatusWithdrawn},
+	StatusOffer:     {StatusAccepted, StatusRejected, StatusWithdrawn},
+}
+
+func (s ApplicationStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusReviewed, StatusInterview, StatusOffer,
+		StatusAccepted, StatusRejected, StatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+func (s ApplicationStatus) CanTransitionTo(next ApplicationStatus) bool {
+	for _, allowed := range applicationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Application struct {
 	Base
 
@@ -17,15 +49,52 @@ type Application struct {
 	VacancyID uint `json:"vacancy_id" gorm:"not null"`
 	ResumeID  uint `json:"resume_id" gorm:"not null"`
 
+	// Оценка соответствия резюме вакансии, полученная от ИИ при отклике.
+	// MatchScore пуст, пока оценка не готова или если её не удалось получить.
+	MatchScore          *int           `json:"match_score" gorm:"index"`
+	MatchedRequirements pq.StringArray `json:"matched_requirements" gorm:"type:text[]"`
+	MissingRequirements pq.StringArray `json:"missing_requirements" gorm:"type:text[]"`
+	MatchJustification  string         `json:"match_justification" gorm:"type:text"`
+
 	Vacancy *Vacancy `json:"vacancy,omitempty" gorm:"foreignKey:VacancyID"`
 	Resume  *Resume  `json:"resume,omitempty" gorm:"foreignKey:ResumeID"`
 }
 
+// ApplicationStatusChange — запись в истории статусов отклика.
+type ApplicationStatusChange struct {
+	Base
+
+	ApplicationID uint              `json:"application_id" gorm:"not null;index"`
+	FromStatus    ApplicationStatus `json:"from_status" gorm:"type:varchar(100);not null;default:''"`
+	ToStatus      ApplicationStatus `json:"to_status" gorm:"type:varchar(100);not null"`
+	ChangedByID   uint              `json:"changed_by_id" gorm:"not null"`
+	ChangedByRole string            `json:"changed_by_role" gorm:"type:varchar(50);not null"`
+	Comment       string            `json:"comment" gorm:"type:text"`
+
+	Application *Application `json:"-" gorm:"foreignKey:ApplicationID;constraint:OnDelete:CASCADE;"`
+}
+
 type CreateApplication struct {
 	VacancyID uint `json:"vacancy_id" binding:"required"`
 	ResumeID  uint `json:"resume_id" binding:"required"`
 }
 
+type ChangeApplicationStatusRequest struct {
+	Status  ApplicationStatus `json:"status" binding:"required,application_status"`
+	Comment string            `json:"comment" binding:"omitempty,max=1000"`
+}
+
+type WithdrawApplicationRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=1000"`
+}
+
+// Варианты сортировки откликов компании.
+const (
+	ApplicationSortDate  = "date"
+	ApplicationSortMatch = "match"
+)
+
 type ApplicationFilter struct {
 	Status *ApplicationStatus `form:"status" binding:"omitempty,application_status"`
+	Sort   string             `form:"sort" binding:"omitempty,oneof=date match"`
 }
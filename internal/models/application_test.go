@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestApplicationStatusCanTransitionTo(t *testing.T) {
+	statuses := []ApplicationStatus{
+		StatusPending, StatusReviewed, StatusInterview, StatusOffer,
+		StatusAccepted, StatusRejected, StatusWithdrawn,
+	}
+
+	tests := []struct {
+		from    ApplicationStatus
+		allowed []ApplicationStatus
+	}{
+		{from: StatusPending, allowed: []ApplicationStatus{StatusReviewed, StatusRejected, StatusWithdrawn}},
+		{from: StatusReviewed, allowed: []ApplicationStatus{StatusInterview, StatusOffer, StatusRejected, StatusWithdrawn}},
+		{from: StatusInterview, allowed: []ApplicationStatus{StatusOffer, StatusRejected, StatusWithdrawn}},
+		{from: StatusOffer, allowed: []ApplicationStatus{StatusAccepted, StatusRejected, StatusWithdrawn}},
+		{from: StatusAccepted},
+		{from: StatusRejected},
+		{from: StatusWithdrawn},
+		{from: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			allowed := map[ApplicationStatus]bool{}
+			for _, next := range tt.allowed {
+				allowed[next] = true
+			}
+			for _, next := range append(statuses, "unknown") {
+				if got := tt.from.CanTransitionTo(next); got != allowed[next] {
+					t.Errorf("%s -> %s = %v, want %v", tt.from, next, got, allowed[next])
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,45 @@
 package models
 
+import "time"
+
+// CompanyImage — вид изображения в профиле компании.
+type CompanyImage string
+
+const (
+	CompanyLogo  CompanyImage = "logo"
+	CompanyCover CompanyImage = "cover"
+)
+
 type Company struct {
 	Base
 
 	Name        string  `json:"name" gorm:"type:varchar(100);not null"`
 	Description string  `json:"description" gorm:"type:varchar(1000);not null"`
 	Website     string  `json:"website" gorm:"type:varchar(255);not null"`
+	Email       string  `json:"email" gorm:"type:varchar(255);not null;default:'';index:idx_companies_email,unique,where:email <> ''"`
+	Password    string  `json:"-" gorm:"type:varchar(255);not null;default:''"`
+	Role        string  `json:"role" gorm:"type:varchar(50);not null;default:'COMPANY'"`
 	Rating      float64 `json:"rating" gorm:"type:double precision"`
 	ReviewCount int     `json:"review_count"`
+	// Locale — язык писем: ru или en.
+	Locale string `json:"locale" gorm:"type:varchar(5);not null;default:'ru'"`
+
+	Industry     string `json:"industry" gorm:"type:varchar(100);not null;default:''"`
+	Size         string `json:"size" gorm:"type:varchar(20);not null;default:''"`
+	Headquarters string `json:"headquarters" gorm:"type:varchar(255);not null;default:''"`
+	// SocialLinks — ссылки на страницы компании: ключ — сеть (linkedin, telegram...).
+	SocialLinks map[string]string `json:"social_links" gorm:"type:jsonb;serializer:json"`
+	LogoURL     string            `json:"logo_url" gorm:"type:varchar(255);not null;default:''"`
+	CoverURL    string            `json:"cover_url" gorm:"type:varchar(255);not null;default:''"`
+
+	// Verified выставляет администратор после проверки компании.
+	Verified   bool       `json:"verified" gorm:"not null;default:false"`
+	VerifiedAt *time.Time `json:"verified_at"`
+
+	// BlockedAt выставляет администратор; вместе с компанией блокируются
+	// её сотрудники.
+	BlockedAt   *time.Time `json:"-" gorm:"index"`
+	BlockReason string     `json:"-" gorm:"type:text;not null;default:''"`
 
 	Vacancies []Vacancy `json:"-" gorm:"constraint:OnDelete:RESTRICT;"`
 }
@@ -19,9 +51,27 @@ type CompanyCreateRequest struct {
 }
 
 type CompanyRegisterRequest struct {
-	Name        string `json:"name"`
+	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
 	Website     string `json:"website"`
 	Email       string `json:"email" binding:"required,email"`
 	Password    string `json:"password" binding:"required,min=8"`
+	Locale      string `json:"locale" binding:"omitempty,oneof=ru en"`
+}
+
+// CompanyUpdateRequest: переданные поля заменяются, остальные остаются
+// без изменений. social_links заменяется целиком.
+type CompanyUpdateRequest struct {
+	Name         *string            `json:"name" binding:"omitempty,min=1,max=100"`
+	Description  *string            `json:"description" binding:"omitempty,max=1000"`
+	Website      *string            `json:"website" binding:"omitempty,max=255"`
+	Locale       *string            `json:"locale" binding:"omitempty,oneof=ru en"`
+	Industry     *string            `json:"industry" binding:"omitempty,max=100"`
+	Size         *string            `json:"size" binding:"omitempty,oneof=1-10 11-50 51-200 201-1000 1000+"`
+	Headquarters *string            `json:"headquarters" binding:"omitempty,max=255"`
+	SocialLinks  *map[string]string `json:"social_links" binding:"omitempty,max=10,dive,keys,oneof=linkedin telegram vk github x facebook youtube habr,endkeys,url,max=255"`
+}
+
+type CompanyVerificationRequest struct {
+	Verified *bool `json:"verified" binding:"required"`
 }
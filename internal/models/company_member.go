@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+// MemberRole — роль сотрудника в команде компании.
+type MemberRole string
+
+const (
+	// MemberOwner управляет командой целиком. Аккаунт самой компании
+	// всегда действует как владелец.
+	MemberOwner MemberRole = "owner"
+	// MemberAdmin приглашает и удаляет рекрутеров и наблюдателей,
+	// назначает рекрутеров на вакансии и ведёт любые отклики.
+	MemberAdmin MemberRole = "admin"
+	// MemberRecruiter ведёт отклики только на назначенные ему вакансии.
+	MemberRecruiter MemberRole = "recruiter"
+	// MemberViewer только просматривает отклики.
+	MemberViewer MemberRole = "viewer"
+)
+
+func (r MemberRole) IsValid() bool {
+	switch r {
+	case MemberOwner, MemberAdmin, MemberRecruiter, MemberViewer:
+		return true
+	}
+	return false
+}
+
+// CanManage сообщает, может ли сотрудник с ролью r приглашать
+// и удалять сотрудников с ролью other.
+func (r MemberRole) CanManage(other MemberRole) bool {
+	switch r {
+	case MemberOwner:
+		return true
+	case MemberAdmin:
+		return other == MemberRecruiter || other == MemberViewer
+	}
+	return false
+}
+
+type MemberStatus string
+
+const (
+	MemberInvited MemberStatus = "invited"
+	MemberActive  MemberStatus = "active"
+)
+
+// CompanyMember — сотрудник компании со своим входом. Почта уникальна
+// среди всех компаний: по ней сотрудник входит в систему.
+type CompanyMember struct {
+	Base
+
+	CompanyID uint         `json:"company_id" gorm:"not null;index"`
+	Email     string       `json:"email" gorm:"type:varchar(255);not null;index:idx_company_members_email,unique,where:deleted_at IS NULL"`
+	Name      string       `json:"name" gorm:"type:varchar(100);not null;default:''"`
+	Password  string       `json:"-" gorm:"type:varchar(255);not null;default:''"`
+	Role      MemberRole   `json:"role" gorm:"type:varchar(20);not null"`
+	Status    MemberStatus `json:"status" gorm:"type:varchar(20);not null;default:'invited'"`
+	// InvitedByID — пригласивший сотрудник; пуст, если приглашала компания.
+	InvitedByID *uint      `json:"invited_by_id"`
+	JoinedAt    *time.Time `json:"joined_at"`
+
+	// VacancyIDs — вакансии, на которые назначен рекрутер.
+	VacancyIDs []uint `json:"vacancy_ids" gorm:"-"`
+
+	Company *Company `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// VacancyRecruiter назначает рекрутера на вакансию.
+type VacancyRecruiter struct {
+	VacancyID uint      `json:"vacancy_id" gorm:"primaryKey"`
+	MemberID  uint      `json:"member_id" gorm:"primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Vacancy *Vacancy       `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	Member  *CompanyMember `json:"-" gorm:"foreignKey:MemberID;constraint:OnDelete:CASCADE;"`
+}
+
+// CompanyActor — тот, кто действует от имени компании: сама компания
+// (MemberID = 0, роль владельца) или её сотрудник.
+type CompanyActor struct {
+	MemberID uint
+	Role     MemberRole
+}
+
+// UserID возвращает субъекта для журнала изменений.
+func (a CompanyActor) UserID(companyId uint) uint {
+	if a.MemberID != 0 {
+		return a.MemberID
+	}
+	return companyId
+}
+
+// UserRole возвращает роль субъекта для журнала изменений.
+func (a CompanyActor) UserRole() string {
+	if a.MemberID != 0 {
+		return RoleCompanyMember
+	}
+	return RoleCompany
+}
+
+type InviteMemberRequest struct {
+	Email string     `json:"email" binding:"required,email,max=255"`
+	Role  MemberRole `json:"role" binding:"required,member_role"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// DataMigration отмечает выполненный перенос данных. Запись создаётся
+// в одной транзакции с переносом, поэтому после сбоя перенос повторится
+// при следующем запуске.
+type DataMigration struct {
+	Name      string    `gorm:"primaryKey;type:varchar(100)"`
+	AppliedAt time.Time `gorm:"type:timestamp with time zone;not null"`
+}
+
+// Переносы данных.
+const DataMigrationLegacySkills = "legacy_resume_skills"
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+type InterviewStatus string
+
+const (
+	InterviewScheduled InterviewStatus = "scheduled"
+	InterviewCancelled InterviewStatus = "cancelled"
+)
+
+// Ограничения на длительность слота собеседования.
+const (
+	MinInterviewDuration = 15 * time.Minute
+	MaxInterviewDuration = 8 * time.Hour
+)
+
+// InterviewSlot — время, которое компания открыла для собеседований
+// по вакансии. Слоты одной компании не пересекаются.
+type InterviewSlot struct {
+	Base
+
+	VacancyID uint      `json:"vacancy_id" gorm:"not null;index"`
+	CompanyID uint      `json:"company_id" gorm:"not null;index:idx_interview_slots_company,priority:1"`
+	StartsAt  time.Time `json:"starts_at" gorm:"type:timestamp with time zone;not null;index:idx_interview_slots_company,priority:2"`
+	EndsAt    time.Time `json:"ends_at" gorm:"type:timestamp with time zone;not null"`
+	Location  string    `json:"location" gorm:"type:text"`
+	Note      string    `json:"note" gorm:"type:text"`
+
+	// Booked вычисляется при выборке: есть ли на слоте назначенное собеседование.
+	Booked bool `json:"booked" gorm:"->;-:migration"`
+}
+
+// Interview — собеседование по отклику на одном из слотов. Время и место
+// копируются из слота. При переносе и отмене увеличивается Sequence,
+// чтобы календари участников обновили уже полученное приглашение.
+type Interview struct {
+	Base
+
+	ApplicationID uint            `json:"application_id" gorm:"not null;uniqueIndex:idx_interviews_active_application,where:status = 'scheduled' AND deleted_at IS NULL"`
+	SlotID        uint            `json:"slot_id" gorm:"not null;uniqueIndex:idx_interviews_active_slot,where:status = 'scheduled' AND deleted_at IS NULL"`
+	VacancyID     uint            `json:"vacancy_id" gorm:"not null"`
+	ApplicantID   uint            `json:"applicant_id" gorm:"not null;index"`
+	CompanyID     uint            `json:"company_id" gorm:"not null;index"`
+	Status        InterviewStatus `json:"status" gorm:"type:varchar(20);not null;default:'scheduled'"`
+
+	StartsAt time.Time `json:"starts_at" gorm:"type:timestamp with time zone;not null"`
+	EndsAt   time.Time `json:"ends_at" gorm:"type:timestamp with time zone;not null"`
+	Location string    `json:"location" gorm:"type:text"`
+	Sequence int       `json:"sequence" gorm:"not null;default:0"`
+
+	CancelledByRole string     `json:"cancelled_by_role,omitempty" gorm:"type:varchar(50)"`
+	CancelReason    string     `json:"cancel_reason,omitempty" gorm:"type:text"`
+	CancelledAt     *time.Time `json:"cancelled_at" gorm:"type:timestamp with time zone"`
+
+	Slot *InterviewSlot `json:"-" gorm:"foreignKey:SlotID"`
+}
+
+type CreateInterviewSlotRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	Location string    `json:"location" binding:"omitempty,max=1000"`
+	Note     string    `json:"note" binding:"omitempty,max=1000"`
+}
+
+type BookInterviewRequest struct {
+	SlotID uint `json:"slot_id" binding:"required"`
+}
+
+type CancelInterviewRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=1000"`
+}
+
+// InterviewSlotFilter — выборка слотов вакансии. По умолчанию
+// возвращаются будущие слоты, и свободные, и занятые.
+type InterviewSlotFilter struct {
+	OnlyFree bool       `form:"only_free"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+}
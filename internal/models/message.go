@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Message — сообщение в переписке по отклику. В переписке две стороны,
+// поэтому отметка о прочтении хранится прямо в сообщении: ReadAt ставит
+// получатель, а отправитель видит её как уведомление о прочтении.
+type Message struct {
+	Base
+
+	ApplicationID uint   `json:"application_id" gorm:"not null;index"`
+	SenderID      uint   `json:"sender_id" gorm:"not null"`
+	SenderRole    string `json:"sender_role" gorm:"type:varchar(50);not null"`
+	Body          string `json:"body" gorm:"type:text"`
+
+	ReadAt *time.Time `json:"read_at" gorm:"type:timestamp with time zone"`
+
+	Attachments []MessageAttachment `json:"attachments" gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE;"`
+
+	Application *Application `json:"-" gorm:"foreignKey:ApplicationID;constraint:OnDelete:CASCADE;"`
+}
+
+// MessageAttachment — ссылка на файл, загруженный во внешнее хранилище.
+// Сами файлы сервис не хранит.
+type MessageAttachment struct {
+	Base
+
+	MessageID   uint   `json:"-" gorm:"not null;index"`
+	Name        string `json:"name" gorm:"type:varchar(255);not null"`
+	URL         string `json:"url" gorm:"type:text;not null"`
+	ContentType string `json:"content_type" gorm:"type:varchar(255)"`
+	Size        int64  `json:"size"`
+}
+
+type MessageAttachmentRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	URL         string `json:"url" binding:"required,max=2048,attachment_url"`
+	ContentType string `json:"content_type" binding:"omitempty,max=255"`
+	Size        int64  `json:"size" binding:"omitempty,min=0"`
+}
+
+type SendMessageRequest struct {
+	Body        string                     `json:"body" binding:"required_without=Attachments,max=5000"`
+	Attachments []MessageAttachmentRequest `json:"attachments" binding:"omitempty,max=10,dive"`
+}
+
+// MessageFilter листает переписку от новых сообщений к старым:
+// BeforeID — ID самого старого уже загруженного сообщения.
+type MessageFilter struct {
+	BeforeID uint `form:"before_id"`
+	Limit    int  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type MessageThread struct {
+	Items  []Message `json:"items"`
+	Unread int64     `json:"unread"`
+}
+
+// MessageUnread — число непрочитанных сообщений в переписке по отклику.
+type MessageUnread struct {
+	ApplicationID uint  `json:"application_id"`
+	Unread        int64 `json:"unread"`
+}
+
+type MessageUnreadSummary struct {
+	Total        int64           `json:"total"`
+	Applications []MessageUnread `json:"applications"`
+}
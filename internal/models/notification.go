@@ -0,0 +1,99 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Типы уведомлений. Имена совпадают с шаблонами писем.
+const (
+	NotificationApplicationReceived      = "application_received"
+	NotificationApplicationStatusChanged = "application_status_changed"
+	NotificationApplicationWithdrawn     = "application_withdrawn"
+	NotificationResumeImproved           = "resume_improved"
+	NotificationInterviewScheduled       = "interview_scheduled"
+	NotificationInterviewRescheduled     = "interview_rescheduled"
+	NotificationInterviewCancelled       = "interview_cancelled"
+	NotificationSavedSearchDigest        = "saved_search_digest"
+)
+
+// notificationTypes — какие уведомления получает каждая роль и отправляются
+// ли они почтой, пока пользователь не изменил настройки.
+var notificationTypes = map[string][]NotificationPreferenceItem{
+	RoleApplicant: {
+		{Type: NotificationApplicationStatusChanged, Email: true},
+		{Type: NotificationResumeImproved, Email: false},
+		{Type: NotificationInterviewScheduled, Email: true},
+		{Type: NotificationInterviewRescheduled, Email: true},
+		{Type: NotificationInterviewCancelled, Email: true},
+		{Type: NotificationSavedSearchDigest, Email: true},
+	},
+	RoleCompany:       companyNotificationTypes,
+	RoleCompanyMember: companyNotificationTypes,
+}
+
+// companyNotificationTypes — уведомления компании; их же получают
+// сотрудники, которые ведут вакансию.
+var companyNotificationTypes = []NotificationPreferenceItem{
+	{Type: NotificationApplicationReceived, Email: true},
+	{Type: NotificationApplicationWithdrawn, Email: true},
+	{Type: NotificationInterviewScheduled, Email: true},
+	{Type: NotificationInterviewRescheduled, Email: true},
+	{Type: NotificationInterviewCancelled, Email: true},
+}
+
+// DefaultNotificationPreferences возвращает настройки роли по умолчанию.
+func DefaultNotificationPreferences(role string) []NotificationPreferenceItem {
+	return slices.Clone(notificationTypes[role])
+}
+
+// Notification — уведомление в приложении. Ссылки на отклик, вакансию
+// и резюме заполняются, если событие к ним относится.
+type Notification struct {
+	Base
+
+	UserID uint   `json:"-" gorm:"not null;index:idx_notifications_recipient,priority:1"`
+	Role   string `json:"-" gorm:"type:varchar(50);not null;index:idx_notifications_recipient,priority:2"`
+
+	Type  string `json:"type" gorm:"type:varchar(50);not null"`
+	Title string `json:"title" gorm:"type:varchar(255);not null"`
+	Body  string `json:"body" gorm:"type:text"`
+
+	ApplicationID *uint `json:"application_id,omitempty"`
+	VacancyID     *uint `json:"vacancy_id,omitempty"`
+	ResumeID      *uint `json:"resume_id,omitempty"`
+	SavedSearchID *uint `json:"saved_search_id,omitempty"`
+
+	ReadAt *time.Time `json:"read_at" gorm:"type:timestamp with time zone"`
+}
+
+// NotificationPreference хранит только изменённые пользователем настройки;
+// для остальных типов действуют значения по умолчанию.
+type NotificationPreference struct {
+	UserID uint   `gorm:"primaryKey;autoIncrement:false"`
+	Role   string `gorm:"primaryKey;type:varchar(50)"`
+	Type   string `gorm:"primaryKey;type:varchar(50)"`
+	Email  bool   `gorm:"not null"`
+
+	Timestamp
+}
+
+type NotificationFilter struct {
+	Unread bool `form:"unread"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type NotificationList struct {
+	Items  []Notification `json:"items"`
+	Unread int64          `json:"unread"`
+}
+
+type NotificationPreferenceItem struct {
+	Type  string `json:"type" binding:"required"`
+	Email bool   `json:"email"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceItem `json:"preferences" binding:"required,dive"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Назначения одноразовых токенов.
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeMemberInvitation  = "member_invitation"
+	TokenPurposeEventStream       = "event_stream"
+)
+
+// OneTimeToken — токен из письма или билет потока событий. В базе
+// хранится только SHA-256 хеш, сам токен знает лишь получатель.
+type OneTimeToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Purpose   string    `gorm:"type:varchar(50);not null;index:idx_one_time_tokens_principal" json:"purpose"`
+
+	UserID uint   `gorm:"not null;index:idx_one_time_tokens_principal" json:"user_id"`
+	Role   string `gorm:"type:varchar(50);not null;index:idx_one_time_tokens_principal" json:"role"`
+
+	ExpiresAt  time.Time  `gorm:"type:timestamp with time zone;not null;index" json:"expires_at"`
+	ConsumedAt *time.Time `gorm:"type:timestamp with time zone" json:"consumed_at"`
+
+	Timestamp
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type OutboxEmailStatus string
+
+const (
+	OutboxEmailPending OutboxEmailStatus = "pending"
+	OutboxEmailSending OutboxEmailStatus = "sending"
+	OutboxEmailSent    OutboxEmailStatus = "sent"
+	OutboxEmailFailed  OutboxEmailStatus = "failed"
+)
+
+// OutboxEmail — письмо, ожидающее отправки. Запись создаётся в той же
+// транзакции, что и изменение, о котором письмо сообщает, поэтому
+// письмо не теряется и не уходит при откате. Отправляет диспетчер
+// в фоне, при ошибках — с повторами.
+type OutboxEmail struct {
+	Base
+
+	Template string            `json:"template" gorm:"type:varchar(100);not null"`
+	To       string            `json:"to" gorm:"type:varchar(255);not null"`
+	Subject  string            `json:"subject" gorm:"type:varchar(255);not null"`
+	HTML     string            `json:"-" gorm:"type:text"`
+	Text     string            `json:"-" gorm:"type:text"`
+	Status   OutboxEmailStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_emails_queue,priority:1"`
+
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null;default:8"`
+	RunAt       time.Time  `json:"run_at" gorm:"type:timestamp with time zone;not null;index:idx_outbox_emails_queue,priority:2"`
+	StartedAt   *time.Time `json:"started_at" gorm:"type:timestamp with time zone"`
+	SentAt      *time.Time `json:"sent_at" gorm:"type:timestamp with time zone"`
+
+	// Attachments — вложения письма в JSON, содержимое в base64.
+	Attachments json.RawMessage `json:"-" gorm:"type:jsonb"`
+
+	Error string `json:"error,omitempty" gorm:"type:text"`
+}
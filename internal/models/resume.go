@@ -1,10 +1,18 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Resume struct {
 	Base
 
-	Position    string `json:"position"`
-	Summary     string `json:"summary"`
+	Position string `json:"position"`
+	Summary  string `json:"summary"`
+	// Skills и Experience — свободный текст из первых версий резюме.
+	// Структурированные данные хранятся в SkillList и WorkExperience.
 	Skills      string `json:"skills"`
 	Experience  string `json:"experience"`
 	Portfolio   string `json:"portfolio"`
@@ -12,6 +20,21 @@ type Resume struct {
 	AIImproved  string `json:"ai_improved"`
 	AIScore     int    `json:"ai_score"`
 	ApplicantID uint   `json:"applicant_id"`
+
+	WorkExperience []WorkExperience `json:"work_experience" gorm:"constraint:OnDelete:CASCADE;"`
+	Education      []Education      `json:"education" gorm:"constraint:OnDelete:CASCADE;"`
+	SkillList      []ResumeSkill    `json:"skill_list" gorm:"constraint:OnDelete:CASCADE;"`
+	Languages      []Language       `json:"languages" gorm:"constraint:OnDelete:CASCADE;"`
+
+	// ExperienceYears — общий стаж в годах, считается по WorkExperience.
+	ExperienceYears float64 `json:"experience_years" gorm:"-"`
+}
+
+// AfterFind вызывается после подгрузки связей, поэтому стаж
+// считается по уже загруженным местам работы.
+func (r *Resume) AfterFind(_ *gorm.DB) error {
+	r.ComputeExperienceYears(time.Now())
+	return nil
 }
 
 type ResumeCreateRequest struct {
@@ -21,8 +44,15 @@ type ResumeCreateRequest struct {
 	Experience string `json:"experience"`
 	Portfolio  string `json:"portfolio"`
 	Salary     int    `json:"salary"`
+
+	WorkExperience []WorkExperienceData `json:"work_experience" binding:"omitempty,dive"`
+	Education      []EducationData      `json:"education" binding:"omitempty,dive"`
+	SkillList      []ResumeSkillData    `json:"skill_list" binding:"omitempty,dive"`
+	Languages      []LanguageData       `json:"languages" binding:"omitempty,dive"`
 }
 
+// ResumeUpdateRequest: переданный раздел заменяется целиком,
+// отсутствующий остаётся без изменений.
 type ResumeUpdateRequest struct {
 	Position   *string `json:"position"`
 	Summary    *string `json:"summary"`
@@ -30,4 +60,18 @@ type ResumeUpdateRequest struct {
 	Experience *string `json:"experience"`
 	Portfolio  *string `json:"portfolio"`
 	Salary     *int    `json:"salary"`
+
+	WorkExperience *[]WorkExperienceData `json:"work_experience" binding:"omitempty,dive"`
+	Education      *[]EducationData      `json:"education" binding:"omitempty,dive"`
+	SkillList      *[]ResumeSkillData    `json:"skill_list" binding:"omitempty,dive"`
+	Languages      *[]LanguageData       `json:"languages" binding:"omitempty,dive"`
+}
+
+// ResumeExportRequest — параметры выгрузки: format (pdf по умолчанию),
+// template (classic по умолчанию) и variant — original или ai
+// (последнее предложение ИИ вместо основных полей).
+type ResumeExportRequest struct {
+	Format   string `form:"format" binding:"omitempty,oneof=pdf html markdown"`
+	Template string `form:"template"`
+	Variant  string `form:"variant" binding:"omitempty,oneof=original ai"`
 }
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type SkillLevel string
+
+const (
+	SkillBeginner     SkillLevel = "beginner"
+	SkillIntermediate SkillLevel = "intermediate"
+	SkillAdvanced     SkillLevel = "advanced"
+	SkillExpert       SkillLevel = "expert"
+)
+
+// IsValid допускает пустой уровень: так импортируются навыки
+// из старого текстового поля skills.
+func (l SkillLevel) IsValid() bool {
+	switch l {
+	case "", SkillBeginner, SkillIntermediate, SkillAdvanced, SkillExpert:
+		return true
+	}
+	return false
+}
+
+type LanguageProficiency string
+
+const (
+	LanguageA1     LanguageProficiency = "A1"
+	LanguageA2     LanguageProficiency = "A2"
+	LanguageB1     LanguageProficiency = "B1"
+	LanguageB2     LanguageProficiency = "B2"
+	LanguageC1     LanguageProficiency = "C1"
+	LanguageC2     LanguageProficiency = "C2"
+	LanguageNative LanguageProficiency = "native"
+)
+
+func (p LanguageProficiency) IsValid() bool {
+	switch p {
+	case LanguageA1, LanguageA2, LanguageB1, LanguageB2, LanguageC1, LanguageC2, LanguageNative:
+		return true
+	}
+	return false
+}
+
+// WorkExperienceData — содержимое места работы без служебных полей.
+// Используется в запросах и в снимках версий резюме.
+type WorkExperienceData struct {
+	Company   string    `json:"company" binding:"required" gorm:"type:varchar(255);not null"`
+	Title     string    `json:"title" binding:"required" gorm:"type:varchar(255);not null"`
+	StartDate time.Time `json:"start_date" binding:"required" gorm:"type:date;not null"`
+	// EndDate пустая, если кандидат работает там до сих пор.
+	EndDate     *time.Time `json:"end_date" binding:"omitempty,gtefield=StartDate" gorm:"type:date"`
+	Description string     `json:"description"`
+}
+
+type WorkExperience struct {
+	ID       uint `json:"id" gorm:"primarykey"`
+	ResumeID uint `json:"-" gorm:"not null;index"`
+	WorkExperienceData
+}
+
+type EducationData struct {
+	Institution string `json:"institution" binding:"required" gorm:"type:varchar(255);not null"`
+	Degree      string `json:"degree" gorm:"type:varchar(255)"`
+	Field       string `json:"field" gorm:"type:varchar(255)"`
+	StartYear   *int   `json:"start_year" binding:"omitempty,min=1900,max=2100"`
+	EndYear     *int   `json:"end_year" binding:"omitempty,min=1900,max=2100"`
+}
+
+type Education struct {
+	ID       uint `json:"id" gorm:"primarykey"`
+	ResumeID uint `json:"-" gorm:"not null;index"`
+	EducationData
+}
+
+type ResumeSkillData struct {
+	Name  string     `json:"name" binding:"required" gorm:"type:varchar(100);not null"`
+	Level SkillLevel `json:"level" binding:"skill_level" gorm:"type:varchar(20)"`
+}
+
+type ResumeSkill struct {
+	ID       uint `json:"id" gorm:"primarykey"`
+	ResumeID uint `json:"-" gorm:"not null;index"`
+	ResumeSkillData
+}
+
+type LanguageData struct {
+	Name        string              `json:"name" binding:"required" gorm:"type:varchar(100);not null"`
+	Proficiency LanguageProficiency `json:"proficiency" binding:"required,language_proficiency" gorm:"type:varchar(20);not null"`
+}
+
+type Language struct {
+	ID       uint `json:"id" gorm:"primarykey"`
+	ResumeID uint `json:"-" gorm:"not null;index"`
+	LanguageData
+}
+
+// ResumeSections — структурированные разделы резюме без служебных полей.
+type ResumeSections struct {
+	WorkExperience []WorkExperienceData `json:"work_experience"`
+	Education      []EducationData      `json:"education"`
+	SkillList      []ResumeSkillData    `json:"skill_list"`
+	Languages      []LanguageData       `json:"languages"`
+}
+
+// Sections снимает структурированные разделы резюме.
+func (r *Resume) Sections() ResumeSections {
+	sections := ResumeSections{
+		WorkExperience: make([]WorkExperienceData, 0, len(r.WorkExperience)),
+		Education:      make([]EducationData, 0, len(r.Education)),
+		SkillList:      make([]ResumeSkillData, 0, len(r.SkillList)),
+		Languages:      make([]LanguageData, 0, len(r.Languages)),
+	}
+	for _, item := range r.WorkExperience {
+		sections.WorkExperience = append(sections.WorkExperience, item.WorkExperienceData)
+	}
+	for _, item := range r.Education {
+		sections.Education = append(sections.Education, item.EducationData)
+	}
+	for _, item := range r.SkillList {
+		sections.SkillList = append(sections.SkillList, item.ResumeSkillData)
+	}
+	for _, item := range r.Languages {
+		sections.Languages = append(sections.Languages, item.LanguageData)
+	}
+
+	return sections
+}
+
+func (r *Resume) SetWorkExperience(items []WorkExperienceData) {
+	r.WorkExperience = make([]WorkExperience, 0, len(items))
+	for _, item := range items {
+		item.StartDate = dateOnly(item.StartDate)
+		if item.EndDate != nil {
+			end := dateOnly(*item.EndDate)
+			item.EndDate = &end
+		}
+		r.WorkExperience = append(r.WorkExperience, WorkExperience{ResumeID: r.ID, WorkExperienceData: item})
+	}
+	r.ComputeExperienceYears(time.Now())
+}
+
+// dateOnly отбрасывает время и часовой пояс: в базе хранится только дата.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r *Resume) SetEducation(items []EducationData) {
+	r.Education = make([]Education, 0, len(items))
+	for _, item := range items {
+		r.Education = append(r.Education, Education{ResumeID: r.ID, EducationData: item})
+	}
+}
+
+func (r *Resume) SetSkillList(items []ResumeSkillData) {
+	r.SkillList = make([]ResumeSkill, 0, len(items))
+	for _, item := range items {
+		r.SkillList = append(r.SkillList, ResumeSkill{ResumeID: r.ID, ResumeSkillData: item})
+	}
+}
+
+func (r *Resume) SetLanguages(items []LanguageData) {
+	r.Languages = make([]Language, 0, len(items))
+	for _, item := range items {
+		r.Languages = append(r.Languages, Language{ResumeID: r.ID, LanguageData: item})
+	}
+}
+
+// SetSections заменяет все структурированные разделы резюме.
+func (r *Resume) SetSections(sections ResumeSections) {
+	r.SetWorkExperience(sections.WorkExperience)
+	r.SetEducation(sections.Education)
+	r.SetSkillList(sections.SkillList)
+	r.SetLanguages(sections.Languages)
+}
+
+// ComputeExperienceYears считает общий стаж по местам работы.
+// Пересекающиеся периоды (совмещение) учитываются один раз.
+func (r *Resume) ComputeExperienceYears(now time.Time) {
+	type period struct{ start, end time.Time }
+
+	periods := make([]period, 0, len(r.WorkExperience))
+	for _, item := range r.WorkExperience {
+		end := now
+		if item.EndDate != nil {
+			end = *item.EndDate
+		}
+		if end.After(item.StartDate) {
+			periods = append(periods, period{start: item.StartDate, end: end})
+		}
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].start.Before(periods[j].start) })
+
+	var total time.Duration
+	var current *period
+	for i := range periods {
+		p := periods[i]
+		switch {
+		case current == nil:
+			current = &p
+		case !p.start.After(current.end):
+			if p.end.After(current.end) {
+				current.end = p.end
+			}
+		default:
+			total += current.end.Sub(current.start)
+			current = &p
+		}
+	}
+	if current != nil {
+		total += current.end.Sub(current.start)
+	}
+
+	years := total.Hours() / 24 / 365.25
+	r.ExperienceYears = math.Round(years*10) / 10
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeExperienceYears(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	job := func(start time.Time, end *time.Time) WorkExperience {
+		return WorkExperience{WorkExperienceData: WorkExperienceData{StartDate: start, EndDate: end}}
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+	now := date(2026, 1, 1)
+
+	tests := []struct {
+		name string
+		jobs []WorkExperience
+		want float64
+	}{
+		{name: "no jobs", want: 0},
+		{
+			name: "single job",
+			jobs: []WorkExperience{job(date(2020, 1, 1), ptr(date(2022, 1, 1)))},
+			want: 2,
+		},
+		{
+			name: "current job",
+			jobs: []WorkExperience{job(date(2024, 1, 1), nil)},
+			want: 2,
+		},
+		{
+			name: "overlapping jobs",
+			jobs: []WorkExperience{
+				job(date(2021, 1, 1), ptr(date(2023, 1, 1))),
+				job(date(2020, 1, 1), ptr(date(2022, 1, 1))),
+			},
+			want: 3,
+		},
+		{
+			name: "nested job",
+			jobs: []WorkExperience{
+				job(date(2018, 1, 1), ptr(date(2024, 1, 1))),
+				job(date(2019, 1, 1), ptr(date(2020, 1, 1))),
+			},
+			want: 6,
+		},
+		{
+			name: "adjacent jobs",
+			jobs: []WorkExperience{
+				job(date(2020, 1, 1), ptr(date(2021, 1, 1))),
+				job(date(2021, 1, 1), ptr(date(2022, 1, 1))),
+			},
+			want: 2,
+		},
+		{
+			name: "gap between jobs",
+			jobs: []WorkExperience{
+				job(date(2015, 1, 1), ptr(date(2016, 1, 1))),
+				job(date(2020, 1, 1), ptr(date(2020, 7, 1))),
+			},
+			want: 1.5,
+		},
+		{
+			name: "empty and future periods",
+			jobs: []WorkExperience{
+				job(date(2022, 1, 1), ptr(date(2022, 1, 1))),
+				job(date(2027, 1, 1), nil),
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resume := Resume{WorkExperience: tt.jobs}
+			resume.ComputeExperienceYears(now)
+			if resume.ExperienceYears != tt.want {
+				t.Errorf("ExperienceYears = %v, want %v", resume.ExperienceYears, tt.want)
+			}
+		})
+	}
+}
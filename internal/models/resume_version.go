@@ -0,0 +1,143 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ResumeVersionSource string
+
+const (
+	ResumeVersionCreated    ResumeVersionSource = "create"
+	ResumeVersionManual     ResumeVersionSource = "manual"
+	ResumeVersionAI         ResumeVersionSource = "ai"
+	ResumeVersionRestored   ResumeVersionSource = "restore"
+	ResumeVersionAIAccepted ResumeVersionSource = "ai_accepted"
+)
+
+var ErrResumeVersionImmutable = errors.New("resume versions are immutable")
+
+// ResumeVersion — неизменяемый снимок полей резюме. Версия с источником
+// ai хранит предложение модели, а не состояние основных полей резюме.
+type ResumeVersion struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ResumeID uint                `json:"resume_id" gorm:"not null;uniqueIndex:idx_resume_versions_number,priority:1"`
+	Number   int                 `json:"number" gorm:"not null;uniqueIndex:idx_resume_versions_number,priority:2"`
+	Source   ResumeVersionSource `json:"source" gorm:"type:varchar(20);not null"`
+	// RestoredFrom — номер версии, из которой восстановлены или приняты поля.
+	RestoredFrom *int `json:"restored_from,omitempty"`
+
+	Position   string `json:"position"`
+	Summary    string `json:"summary"`
+	Skills     string `json:"skills"`
+	Experience string `json:"experience"`
+	Portfolio  string `json:"portfolio"`
+	Salary     int    `json:"salary"`
+	AIImproved string `json:"ai_improved"`
+	AIScore    int    `json:"ai_score"`
+	// Sections пуст у версий, снятых до появления структурированных разделов.
+	Sections *ResumeSections `json:"sections,omitempty" gorm:"type:jsonb;serializer:json"`
+
+	Resume *Resume `json:"-" gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE;"`
+}
+
+func (ResumeVersion) BeforeUpdate(_ *gorm.DB) error {
+	return ErrResumeVersionImmutable
+}
+
+func (ResumeVersion) BeforeDelete(_ *gorm.DB) error {
+	return ErrResumeVersionImmutable
+}
+
+// ResumeFieldDiff — различие одного поля между двумя версиями.
+type ResumeFieldDiff struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type ResumeVersionDiff struct {
+	ResumeID uint              `json:"resume_id"`
+	From     int               `json:"from"`
+	To       int               `json:"to"`
+	Changes  []ResumeFieldDiff `json:"changes"`
+}
+
+// NewResumeVersion снимает текущее состояние полей резюме.
+func NewResumeVersion(resume *Resume, source ResumeVersionSource) *ResumeVersion {
+	sections := resume.Sections()
+
+	return &ResumeVersion{
+		ResumeID:   resume.ID,
+		Source:     source,
+		Position:   resume.Position,
+		Summary:    resume.Summary,
+		Skills:     resume.Skills,
+		Experience: resume.Experience,
+		Portfolio:  resume.Portfolio,
+		Salary:     resume.Salary,
+		AIImproved: resume.AIImproved,
+		AIScore:    resume.AIScore,
+		Sections:   &sections,
+	}
+}
+
+// ApplyTo переносит поля версии в основные поля резюме.
+func (v *ResumeVersion) ApplyTo(resume *Resume) {
+	resume.Position = v.Position
+	resume.Summary = v.Summary
+	resume.Skills = v.Skills
+	resume.Experience = v.Experience
+	resume.Portfolio = v.Portfolio
+	resume.Salary = v.Salary
+	if v.Sections != nil {
+		resume.SetSections(*v.Sections)
+	}
+}
+
+// Diff сравнивает основные поля двух версий.
+func (v *ResumeVersion) Diff(other *ResumeVersion) ResumeVersionDiff {
+	diff := ResumeVersionDiff{
+		ResumeID: v.ResumeID,
+		From:     v.Number,
+		To:       other.Number,
+		Changes:  []ResumeFieldDiff{},
+	}
+
+	add := func(field string, from, to any) {
+		if !sameJSON(from, to) {
+			diff.Changes = append(diff.Changes, ResumeFieldDiff{Field: field, From: from, To: to})
+		}
+	}
+
+	add("position", v.Position, other.Position)
+	add("summary", v.Summary, other.Summary)
+	add("skills", v.Skills, other.Skills)
+	add("experience", v.Experience, other.Experience)
+	add("portfolio", v.Portfolio, other.Portfolio)
+	add("salary", v.Salary, other.Salary)
+	add("ai_improved", v.AIImproved, other.AIImproved)
+	add("ai_score", v.AIScore, other.AIScore)
+	if v.Sections != nil && other.Sections != nil {
+		add("work_experience", v.Sections.WorkExperience, other.Sections.WorkExperience)
+		add("education", v.Sections.Education, other.Sections.Education)
+		add("skill_list", v.Sections.SkillList, other.Sections.SkillList)
+		add("languages", v.Sections.Languages, other.Sections.Languages)
+	}
+
+	return diff
+}
+
+// sameJSON сравнивает значения по их JSON-представлению: разделы резюме —
+// слайсы структур, и через != их не сравнить.
+func sameJSON(a, b any) bool {
+	left, _ := json.Marshal(a)
+	right, _ := json.Marshal(b)
+	return bytes.Equal(left, right)
+}
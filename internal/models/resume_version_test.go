@@ -0,0 +1,86 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResumeVersionDiff(t *testing.T) {
+	sections := func(institution string) *ResumeSections {
+		return &ResumeSections{
+			WorkExperience: []WorkExperienceData{{
+				Company:   "Acme",
+				Title:     "Go-разработчик",
+				StartDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			}},
+			Education: []EducationData{{Institution: institution}},
+			SkillList: []ResumeSkillData{},
+			Languages: []LanguageData{},
+		}
+	}
+	base := ResumeVersion{
+		ResumeID: 5,
+		Number:   1,
+		Position: "Go-разработчик",
+		Summary:  "Бэкенд",
+		Salary:   200000,
+		Sections: sections("МГУ"),
+	}
+
+	tests := []struct {
+		name   string
+		change func(v *ResumeVersion)
+		want   []ResumeFieldDiff
+	}{
+		{
+			name:   "no changes",
+			change: func(v *ResumeVersion) { v.Sections = sections("МГУ") },
+			want:   []ResumeFieldDiff{},
+		},
+		{
+			name: "scalar fields",
+			change: func(v *ResumeVersion) {
+				v.Position = "Senior Go-разработчик"
+				v.Salary = 300000
+			},
+			want: []ResumeFieldDiff{
+				{Field: "position", From: "Go-разработчик", To: "Senior Go-разработчик"},
+				{Field: "salary", From: 200000, To: 300000},
+			},
+		},
+		{
+			name:   "sections",
+			change: func(v *ResumeVersion) { v.Sections = sections("МФТИ") },
+			want: []ResumeFieldDiff{{
+				Field: "education",
+				From:  []EducationData{{Institution: "МГУ"}},
+				To:    []EducationData{{Institution: "МФТИ"}},
+			}},
+		},
+		{
+			name: "version without sections",
+			change: func(v *ResumeVersion) {
+				v.Sections = nil
+				v.Summary = ""
+			},
+			want: []ResumeFieldDiff{{Field: "summary", From: "Бэкенд", To: ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			other.Number = 3
+			tt.change(&other)
+
+			diff := base.Diff(&other)
+			if diff.ResumeID != 5 || diff.From != 1 || diff.To != 3 {
+				t.Errorf("Diff() header = %d %d→%d, want 5 1→3", diff.ResumeID, diff.From, diff.To)
+			}
+			if !reflect.DeepEqual(diff.Changes, tt.want) {
+				t.Errorf("Diff().Changes = %+v, want %+v", diff.Changes, tt.want)
+			}
+		})
+	}
+}
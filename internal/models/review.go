@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+type ReviewStatus string
+
+// Отзыв попадает в рейтинг и в публичный список только после модерации.
+const (
+	ReviewPending   ReviewStatus = "pending"
+	ReviewPublished ReviewStatus = "published"
+	ReviewRejected  ReviewStatus = "rejected"
+)
+
+// Варианты сортировки отзывов.
+const (
+	ReviewSortDate   = "date"
+	ReviewSortRating = "rating"
+)
+
+// CompanyReview — отзыв соискателя о компании. От одного соискателя
+// у компании может быть только один отзыв.
+type CompanyReview struct {
+	Base
+
+	CompanyID   uint `json:"company_id" gorm:"not null;uniqueIndex:idx_company_reviews_author,priority:1,where:deleted_at IS NULL"`
+	ApplicantID uint `json:"-" gorm:"not null;uniqueIndex:idx_company_reviews_author,priority:2,where:deleted_at IS NULL"`
+
+	// Rating — общая оценка, из неё складывается рейтинг компании.
+	// Остальные оценки показываются отдельно.
+	Rating          int `json:"rating" gorm:"type:smallint;not null"`
+	SalaryScore     int `json:"salary_score" gorm:"type:smallint;not null"`
+	CultureScore    int `json:"culture_score" gorm:"type:smallint;not null"`
+	GrowthScore     int `json:"growth_score" gorm:"type:smallint;not null"`
+	ManagementScore int `json:"management_score" gorm:"type:smallint;not null"`
+
+	Title string `json:"title" gorm:"type:varchar(255);not null;default:''"`
+	Pros  string `json:"pros" gorm:"type:text"`
+	Cons  string `json:"cons" gorm:"type:text"`
+	Text  string `json:"text" gorm:"type:text;not null"`
+
+	Status            ReviewStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	ModerationComment string       `json:"moderation_comment,omitempty" gorm:"type:text"`
+	ModeratedAt       *time.Time   `json:"moderated_at,omitempty"`
+
+	Reply     string     `json:"reply,omitempty" gorm:"type:text"`
+	RepliedAt *time.Time `json:"replied_at,omitempty"`
+}
+
+type CreateReviewRequest struct {
+	Rating          int    `json:"rating" binding:"required,min=1,max=5"`
+	SalaryScore     int    `json:"salary_score" binding:"required,min=1,max=5"`
+	CultureScore    int    `json:"culture_score" binding:"required,min=1,max=5"`
+	GrowthScore     int    `json:"growth_score" binding:"required,min=1,max=5"`
+	ManagementScore int    `json:"management_score" binding:"required,min=1,max=5"`
+	Title           string `json:"title" binding:"max=255"`
+	Pros            string `json:"pros" binding:"max=2000"`
+	Cons            string `json:"cons" binding:"max=2000"`
+	Text            string `json:"text" binding:"required,max=5000"`
+}
+
+type ReplyReviewRequest struct {
+	Reply string `json:"reply" binding:"required,max=5000"`
+}
+
+type ModerateReviewRequest struct {
+	Status  ReviewStatus `json:"status" binding:"required,oneof=published rejected"`
+	Comment string       `json:"comment" binding:"max=1000"`
+}
+
+// ReviewFilter: публичный список содержит только опубликованные отзывы,
+// Status учитывается в списке для модерации.
+type ReviewFilter struct {
+	Status ReviewStatus `form:"status" binding:"omitempty,oneof=pending published rejected"`
+	Sort   string       `form:"sort" binding:"omitempty,oneof=date rating"`
+	Order  string       `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit  int          `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int          `form:"offset" binding:"omitempty,min=0"`
+}
+
+type ReviewList struct {
+	Items []CompanyReview `json:"items"`
+	Total int64           `json:"total"`
+}
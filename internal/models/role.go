@@ -0,0 +1,20 @@
+package models
+
+// Роли субъектов, которые попадают в claim "role" access-токена.
+const (
+	RoleApplicant = "APPLICANT"
+	RoleCompany   = "COMPANY"
+	// RoleCompanyMember — сотрудник компании; user_id в токене — ID
+	// записи CompanyMember, а не компании.
+	RoleCompanyMember = "COMPANY_MEMBER"
+	RoleAdmin         = "ADMIN"
+)
+
+// IsPrincipalRole сообщает, может ли роль стоять в access-токене.
+func IsPrincipalRole(role string) bool {
+	switch role {
+	case RoleApplicant, RoleCompany, RoleCompanyMember, RoleAdmin:
+		return true
+	}
+	return false
+}
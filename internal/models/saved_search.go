@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// SavedVacancy — закладка соискателя на вакансию. Закладка остаётся
+// и после закрытия вакансии, чтобы соискатель видел её статус.
+type SavedVacancy struct {
+	ApplicantID uint      `json:"-" gorm:"primaryKey"`
+	VacancyID   uint      `json:"vacancy_id" gorm:"primaryKey;index"`
+	CreatedAt   time.Time `json:"saved_at"`
+
+	Applicant *Applicant `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+	Vacancy   *Vacancy   `json:"vacancy,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+type DigestFrequency string
+
+const (
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// Period — промежуток между подборками.
+func (f DigestFrequency) Period() time.Duration {
+	if f == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// SavedSearch — сохранённый поиск вакансий. Раз в сутки или в неделю
+// соискатель получает подборку вакансий, появившихся с LastRunAt.
+type SavedSearch struct {
+	Base
+
+	ApplicantID uint            `json:"-" gorm:"not null;index"`
+	Name        string          `json:"name" gorm:"type:varchar(255);not null"`
+	Filter      VacancyFilter   `json:"filter" gorm:"type:jsonb;serializer:json;not null"`
+	Frequency   DigestFrequency `json:"frequency" gorm:"type:varchar(20);not null"`
+	LastRunAt   time.Time       `json:"last_run_at" gorm:"not null"`
+	NextRunAt   time.Time       `json:"next_run_at" gorm:"not null;index"`
+
+	Applicant *Applicant `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+type SavedSearchRequest struct {
+	Name      string          `json:"name" binding:"required,max=255"`
+	Filter    VacancyFilter   `json:"filter"`
+	Frequency DigestFrequency `json:"frequency" binding:"required,oneof=daily weekly"`
+}
+
+type SavedVacancyFilter struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+type SavedVacancyList struct {
+	Items []SavedVacancy `json:"items"`
+	Total int64          `json:"total"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session — вход с одного устройства. Все refresh-токены, выданные
+// при ротации в рамках входа, составляют одно семейство с общим SessionID.
+type Session struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	UserID uint      `gorm:"not null;index:idx_sessions_principal" json:"-"`
+	Role   string    `gorm:"type:varchar(50);not null;index:idx_sessions_principal" json:"-"`
+
+	Device    string `gorm:"type:varchar(255)" json:"device"`
+	UserAgent string `gorm:"type:text" json:"user_agent"`
+	IP        string `gorm:"type:varchar(64)" json:"ip"`
+
+	LastUsedAt time.Time  `gorm:"type:timestamp with time zone;not null" json:"last_used_at"`
+	ExpiresAt  time.Time  `gorm:"type:timestamp with time zone;not null;index" json:"expires_at"`
+	RevokedAt  *time.Time `gorm:"type:timestamp with time zone" json:"-"`
+	// RevokeReason: logout, user, reuse, password_reset.
+	RevokeReason string `gorm:"type:varchar(50)" json:"-"`
+
+	Timestamp
+
+	// Current отмечает сессию, с которой сделан запрос.
+	Current bool `gorm:"-" json:"current"`
+}
+
+const (
+	SessionRevokedByLogout        = "logout"
+	SessionRevokedByUser          = "user"
+	SessionRevokedByReuse         = "reuse"
+	SessionRevokedByPasswordReset = "password_reset"
+)
@@ -6,13 +6,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// RefreshToken принадлежит либо соискателю, либо компании: UserID
+// указывает на запись в таблице, которая определяется по Role.
 type RefreshToken struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	Token     string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"token"`
 	ExpiresAt time.Time `gorm:"type:timestamp with time zone;not null" json:"expires_at"`
 
-	UserID uint       `gorm:"not null" json:"user_id"`
-	User   *Applicant `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;" json:"user,omitempty"`
+	UserID uint   `gorm:"not null;index:idx_refresh_tokens_principal" json:"user_id"`
+	Role   string `gorm:"type:varchar(50);not null;default:'APPLICANT';index:idx_refresh_tokens_principal" json:"role"`
+
+	// SessionID — семейство токенов. Пустой у токенов, выданных до появления сессий.
+	SessionID *uuid.UUID `gorm:"type:uuid;index" json:"session_id"`
+	// RotatedAt проставляется при обмене токена на новый. Использованный
+	// токен хранится до истечения срока, чтобы распознать его повторное
+	// предъявление.
+	RotatedAt *time.Time `gorm:"type:timestamp with time zone" json:"-"`
 
 	Timestamp
 }
@@ -1,32 +1,260 @@
 package models
 
-import "github.com/lib/pq"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type VacancyStatus string
+
+const (
+	VacancyDraft     VacancyStatus = "draft"
+	VacancyPublished VacancyStatus = "published"
+	VacancyPaused    VacancyStatus = "paused"
+	VacancyClosed    VacancyStatus = "closed"
+	VacancyArchived  VacancyStatus = "archived"
+	// VacancyTakenDown — снята администратором; компания не может её
+	// вернуть или изменить.
+	VacancyTakenDown VacancyStatus = "taken_down"
+)
+
+// vacancyTransitions — допустимые переходы статусов вакансии.
+// archived и taken_down конечные: такая вакансия остаётся только
+// в истории откликов.
+var vacancyTransitions = map[VacancyStatus][]VacancyStatus{
+	VacancyDraft:     {VacancyPublished, VacancyArchived},
+	VacancyPublished: {VacancyPaused, VacancyClosed},
+	VacancyPaused:    {VacancyPublished, VacancyClosed},
+	VacancyClosed:    {VacancyPublished, VacancyArchived},
+}
+
+func (s VacancyStatus) IsValid() bool {
+	switch s {
+	case VacancyDraft, VacancyPublished, VacancyPaused, VacancyClosed, VacancyArchived, VacancyTakenDown:
+		return true
+	}
+	return false
+}
+
+func (s VacancyStatus) CanTransitionTo(next VacancyStatus) bool {
+	for _, allowed := range vacancyTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEditable — закрытую и архивную вакансию править нельзя.
+func (s VacancyStatus) IsEditable() bool {
+	return s == VacancyDraft || s == VacancyPublished || s == VacancyPaused
+}
+
+type WorkMode string
+
+const (
+	WorkModeOffice WorkMode = "office"
+	WorkModeHybrid WorkMode = "hybrid"
+	WorkModeRemote WorkMode = "remote"
+)
+
+func (m WorkMode) IsValid() bool {
+	switch m {
+	case WorkModeOffice, WorkModeHybrid, WorkModeRemote:
+		return true
+	}
+	return false
+}
+
+type EmploymentType string
+
+const (
+	EmploymentFullTime   EmploymentType = "full_time"
+	EmploymentPartTime   EmploymentType = "part_time"
+	EmploymentContract   EmploymentType = "contract"
+	EmploymentInternship EmploymentType = "internship"
+)
+
+func (t EmploymentType) IsValid() bool {
+	switch t {
+	case EmploymentFullTime, EmploymentPartTime, EmploymentContract, EmploymentInternship:
+		return true
+	}
+	return false
+}
+
+// ExperienceLevel — требуемый опыт. Пустой уровень означает «не важен».
+type ExperienceLevel string
+
+const (
+	ExperienceNone   ExperienceLevel = "no_experience"
+	ExperienceJunior ExperienceLevel = "junior"
+	ExperienceMiddle ExperienceLevel = "middle"
+	ExperienceSenior ExperienceLevel = "senior"
+	ExperienceLead   ExperienceLevel = "lead"
+)
+
+// IsValid допускает пустой уровень, чтобы в PATCH требование
+// к опыту можно было снять.
+func (l ExperienceLevel) IsValid() bool {
+	switch l {
+	case "", ExperienceNone, ExperienceJunior, ExperienceMiddle, ExperienceSenior, ExperienceLead:
+		return true
+	}
+	return false
+}
+
+type Currency string
+
+const (
+	CurrencyRUB Currency = "RUB"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+func (c Currency) IsValid() bool {
+	switch c {
+	case CurrencyRUB, CurrencyUSD, CurrencyEUR:
+		return true
+	}
+	return false
+}
 
 type Vacancy struct {
 	Base
 
+	// По умолчанию published, чтобы вакансии, созданные до появления
+	// статусов, остались в поиске. Новые вакансии создаются черновиками.
+	Status      VacancyStatus `json:"status" gorm:"type:varchar(50);not null;default:'published';index"`
+	ExpiresAt   *time.Time    `json:"expires_at" gorm:"index"`
+	PublishedAt *time.Time    `json:"published_at"`
+	ClosedAt    *time.Time    `json:"closed_at"`
+	// TakedownReason — причина снятия вакансии администратором.
+	TakedownReason string     `json:"takedown_reason,omitempty" gorm:"type:text;not null;default:''"`
+	TakenDownAt    *time.Time `json:"taken_down_at,omitempty"`
+
 	Title            string         `json:"title" gorm:"type:varchar(255);not null"`
 	Description      string         `json:"description" gorm:"type:text;not null"`
-	Salary           int            `json:"salary" gorm:"type:int;not null"`
 	Rating           float64        `json:"rating" gorm:"type:float;not null"`
 	Requirements     pq.StringArray `json:"requirements" gorm:"type:text[];not null"`
 	Responsibilities pq.StringArray `json:"responsibilities" gorm:"type:text[];not null"`
 	NiceToHave       pq.StringArray `json:"nice_to_have" gorm:"type:text[];not null"`
 
-	CompanyID uint    `json:"company_id" binding:"required" gorm:"not null"`
+	// Вилка зарплаты: любая из границ может быть не указана.
+	// SalaryGross — сумма до вычета налогов.
+	SalaryMin      *int     `json:"salary_min" gorm:"index"`
+	SalaryMax      *int     `json:"salary_max" gorm:"index"`
+	SalaryCurrency Currency `json:"salary_currency" gorm:"type:varchar(3);not null;default:'RUB'"`
+	SalaryGross    bool     `json:"salary_gross" gorm:"not null;default:false"`
+
+	City            string          `json:"city" gorm:"type:varchar(255);not null;default:'';index"`
+	WorkMode        WorkMode        `json:"work_mode" gorm:"type:varchar(20);not null;default:'office';index"`
+	EmploymentType  EmploymentType  `json:"employment_type" gorm:"type:varchar(20);not null;default:'full_time';index"`
+	ExperienceLevel ExperienceLevel `json:"experience_level" gorm:"type:varchar(20);not null;default:'';index"`
+
+	CompanyID uint     `json:"company_id" binding:"required" gorm:"not null"`
 	Company   *Company `json:"-"`
 }
 
+// IsOpen — вакансия опубликована и не истекла: её видно в поиске
+// и на неё можно откликнуться.
+func (v *Vacancy) IsOpen(now time.Time) bool {
+	return v.Status == VacancyPublished && (v.ExpiresAt == nil || v.ExpiresAt.After(now))
+}
+
+// VacancyCreateRequest: без Publish вакансия сохраняется черновиком.
+// Без work_mode и employment_type вакансия считается офисной
+// с полной занятостью, без salary_currency — в рублях.
 type VacancyCreateRequest struct {
-	Title            string   `json:"title" binding:"required"`
-	Description      string   `json:"description" binding:"required"`
-	Salary           int      `json:"salary" binding:"required,gt=0"`
-	CompanyID        uint     `json:"company_id" binding:"required"`
-	Requirements     []string `json:"requirements" binding:"required"`
-	Responsibilities []string `json:"responsibilities" binding:"required"`
-	NiceToHave       []string `json:"nice_to_have" binding:"required"`
+	Title            string     `json:"title" binding:"required"`
+	Description      string     `json:"description" binding:"required"`
+	CompanyID        uint       `json:"company_id" binding:"required"`
+	Requirements     []string   `json:"requirements" binding:"required"`
+	Responsibilities []string   `json:"responsibilities" binding:"required"`
+	NiceToHave       []string   `json:"nice_to_have" binding:"required"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	Publish          bool       `json:"publish"`
+
+	SalaryMin      *int     `json:"salary_min" binding:"omitempty,gt=0"`
+	SalaryMax      *int     `json:"salary_max" binding:"omitempty,gt=0"`
+	SalaryCurrency Currency `json:"salary_currency" binding:"omitempty,currency"`
+	SalaryGross    bool     `json:"salary_gross"`
+
+	City            string          `json:"city" binding:"max=255"`
+	WorkMode        WorkMode        `json:"work_mode" binding:"omitempty,work_mode"`
+	EmploymentType  EmploymentType  `json:"employment_type" binding:"omitempty,employment_type"`
+	ExperienceLevel ExperienceLevel `json:"experience_level" binding:"omitempty,experience_level"`
 }
 
+// VacancyUpdateRequest: переданные поля заменяются, остальные
+// остаются без изменений.
+type VacancyUpdateRequest struct {
+	Title            *string    `json:"title" binding:"omitempty,min=1"`
+	Description      *string    `json:"description" binding:"omitempty,min=1"`
+	Requirements     *[]string  `json:"requirements"`
+	Responsibilities *[]string  `json:"responsibilities"`
+	NiceToHave       *[]string  `json:"nice_to_have"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+
+	SalaryMin      *int      `json:"salary_min" binding:"omitempty,gt=0"`
+	SalaryMax      *int      `json:"salary_max" binding:"omitempty,gt=0"`
+	SalaryCurrency *Currency `json:"salary_currency" binding:"omitempty,currency"`
+	SalaryGross    *bool     `json:"salary_gross"`
+
+	City            *string          `json:"city" binding:"omitempty,max=255"`
+	WorkMode        *WorkMode        `json:"work_mode" binding:"omitempty,work_mode"`
+	EmploymentType  *EmploymentType  `json:"employment_type" binding:"omitempty,employment_type"`
+	ExperienceLevel *ExperienceLevel `json:"experience_level" binding:"omitempty,experience_level"`
+}
+
+// CompanyVacancyFilter — вакансии компании для её владельца,
+// в том числе черновики и закрытые.
+type CompanyVacancyFilter struct {
+	Status VacancyStatus `form:"status" binding:"omitempty,oneof=draft published paused closed archived taken_down"`
+}
+
+// Варианты сортировки в поиске вакансий.
+const (
+	VacancySortRelevance = "relevance"
+	VacancySortSalary    = "salary"
+	VacancySortDate      = "date"
+	VacancySortRating    = "rating"
+)
+
+// VacancyFilter — условия поиска вакансий. Сохранённые поиски хранят
+// фильтр в JSON; сортировка, пагинация и даты публикации в него не входят.
 type VacancyFilter struct {
-	Title *string `form:"title"`
+	Title *string `form:"title" json:"title,omitempty"`
+	// Query — полнотекстовый запрос по названию, описанию, требованиям и обязанностям.
+	Query *string `form:"q" json:"q,omitempty"`
+	// SalaryMin и SalaryMax отбирают вакансии, вилка которых пересекается
+	// с заданной; вакансии без зарплаты при этом не показываются.
+	SalaryMin      *int       `form:"salary_min" json:"salary_min,omitempty" binding:"omitempty,gte=0"`
+	SalaryMax      *int       `form:"salary_max" json:"salary_max,omitempty" binding:"omitempty,gte=0"`
+	SalaryCurrency *Currency  `form:"salary_currency" json:"salary_currency,omitempty" binding:"omitempty,currency"`
+	CompanyID      *uint      `form:"company_id" json:"company_id,omitempty"`
+	PostedSince    *time.Time `form:"posted_since" json:"-" time_format:"2006-01-02"`
+	Skills         []string   `form:"skills" json:"skills,omitempty"`
+
+	City             *string           `form:"city" json:"city,omitempty"`
+	WorkModes        []WorkMode        `form:"work_mode" json:"work_mode,omitempty" binding:"omitempty,dive,work_mode"`
+	EmploymentTypes  []EmploymentType  `form:"employment_type" json:"employment_type,omitempty" binding:"omitempty,dive,employment_type"`
+	ExperienceLevels []ExperienceLevel `form:"experience_level" json:"experience_level,omitempty" binding:"omitempty,dive,experience_level"`
+
+	Sort   string `form:"sort" json:"-" binding:"omitempty,oneof=relevance salary date rating"`
+	Order  string `form:"order" json:"-" binding:"omitempty,oneof=asc desc"`
+	Cursor string `form:"cursor" json:"-"`
+	Limit  int    `form:"limit" json:"-" binding:"omitempty,min=1,max=100"`
+
+	// PublishedSince и PublishedBefore — окно публикации для подборок
+	// сохранённых поисков; из запроса не заполняются.
+	PublishedSince  *time.Time `form:"-" json:"-"`
+	PublishedBefore *time.Time `form:"-" json:"-"`
+}
+
+type VacancySearchResult struct {
+	Items      []Vacancy `json:"items"`
+	Total      int64     `json:"total"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
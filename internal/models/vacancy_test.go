@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestVacancyStatusCanTransitionTo(t *testing.T) {
+	statuses := []VacancyStatus{
+		VacancyDraft, VacancyPublished, VacancyPaused,
+		VacancyClosed, VacancyArchived, VacancyTakenDown,
+	}
+
+	tests := []struct {
+		from    VacancyStatus
+		allowed []VacancyStatus
+	}{
+		{from: VacancyDraft, allowed: []VacancyStatus{VacancyPublished, VacancyArchived}},
+		{from: VacancyPublished, allowed: []VacancyStatus{VacancyPaused, VacancyClosed}},
+		{from: VacancyPaused, allowed: []VacancyStatus{VacancyPublished, VacancyClosed}},
+		{from: VacancyClosed, allowed: []VacancyStatus{VacancyPublished, VacancyArchived}},
+		{from: VacancyArchived},
+		// В taken_down не ведёт ни один переход: вакансию снимает
+		// администратор в обход них.
+		{from: VacancyTakenDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			allowed := map[VacancyStatus]bool{}
+			for _, next := range tt.allowed {
+				allowed[next] = true
+			}
+			for _, next := range statuses {
+				if got := tt.from.CanTransitionTo(next); got != allowed[next] {
+					t.Errorf("%s -> %s = %v, want %v", tt.from, next, got, allowed[next])
+				}
+			}
+		})
+	}
+}
@@ -48,3 +48,13 @@ func UploadFile(file *multipart.FileHeader, path string) error {
 func GetExtensions(filename string) string {
 	return strings.Split(filename, ".")[len(strings.Split(filename, "."))-1]
 }
+
+// DeleteFile удаляет файл, сохранённый UploadFile по тому же path.
+// Отсутствующий файл ошибкой не считается.
+func DeleteFile(path string) error {
+	err := os.Remove(fmt.Sprintf("%s/%s", PATH, path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+)
+
+const defaultAdminListLimit = 20
+
+// accountTables — таблица и колонки списка аккаунтов для каждой роли.
+var accountTables = map[string]struct {
+	table    string
+	name     string
+	verified string
+}{
+	models.RoleApplicant: {table: "applicants", name: "full_name", verified: "is_verified"},
+	models.RoleCompany:   {table: "companies", name: "name", verified: "verified"},
+}
+
+// AdminRepository — аккаунты администраторов, журнал их действий
+// и данные для модерации: списки и блокировка аккаунтов, статистика.
+type AdminRepository interface {
+	Create(ctx context.Context, admin *models.Admin) error
+	GetByEmail(ctx context.Context, email string) (models.Admin, error)
+	Record(ctx context.Context, action *models.AdminAction) error
+	Actions(ctx context.Context, filter models.AdminActionFilter) (*models.AdminActionList, error)
+
+	Accounts(ctx context.Context, role string, filter models.AccountFilter) (*models.AccountList, error)
+	SetBlocked(ctx context.Context, role string, id uint, blocked bool, reason string) error
+	IsBlocked(ctx context.Context, userId uint, role string) (bool, error)
+	Exists(ctx context.Context, userId uint, role string) (bool, error)
+	Stats(ctx context.Context) (*models.PlatformStats, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+func (r *adminRepository) Create(ctx context.Context, admin *models.Admin) error {
+	return r.db.WithContext(ctx).Create(admin).Error
+}
+
+func (r *adminRepository) GetByEmail(ctx context.Context, email string) (models.Admin, error) {
+	var admin models.Admin
+	err := r.db.WithContext(ctx).Where("email = ?", email).Take(&admin).Error
+	return admin, err
+}
+
+func (r *adminRepository) Record(ctx context.Context, action *models.AdminAction) error {
+	return r.db.WithContext(ctx).Create(action).Error
+}
+
+// Actions возвращает журнал от новых записей к старым.
+func (r *adminRepository) Actions(ctx context.Context, filter models.AdminActionFilter) (*models.AdminActionList, error) {
+	query := r.db.WithContext(ctx).Model(&models.AdminAction{})
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAdminListLimit
+	}
+
+	items := make([]models.AdminAction, 0, limit)
+	if err := query.Order("id DESC").Limit(limit).Offset(filter.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return &models.AdminActionList{Items: items, Total: total}, nil
+}
+
+// Accounts возвращает соискателей или компании, новые первыми.
+func (r *adminRepository) Accounts(ctx context.Context, role string, filter models.AccountFilter) (*models.AccountList, error) {
+	source, ok := accountTables[role]
+	if !ok {
+		return &models.AccountList{Items: []models.AccountSummary{}}, nil
+	}
+
+	query := r.db.WithContext(ctx).Table(source.table).Where("deleted_at IS NULL")
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where(source.name+" ILIKE ? OR email ILIKE ?", pattern, pattern)
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			query = query.Where("blocked_at IS NOT NULL")
+		} else {
+			query = query.Where("blocked_at IS NULL")
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAdminListLimit
+	}
+
+	items := make([]models.AccountSummary, 0, limit)
+	err := query.
+		Select("id, " + source.name + " AS name, email, " + source.verified + " AS verified, blocked_at, block_reason, created_at").
+		Order("id DESC").Limit(limit).Offset(filter.Offset).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Role = role
+	}
+
+	return &models.AccountList{Items: items, Total: total}, nil
+}
+
+// SetBlocked блокирует или разблокирует соискателя либо компанию.
+func (r *adminRepository) SetBlocked(ctx context.Context, role string, id uint, blocked bool, reason string) error {
+	source, ok := accountTables[role]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	updates := map[string]any{"blocked_at": nil, "block_reason": ""}
+	if blocked {
+		updates = map[string]any{"blocked_at": time.Now(), "block_reason": reason}
+	}
+
+	result := r.db.WithContext(ctx).Table(source.table).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// IsBlocked проверяет блокировку субъекта токена. Сотрудник
+// заблокирован вместе со своей компанией.
+func (r *adminRepository) IsBlocked(ctx context.Context, userId uint, role string) (bool, error) {
+	db := r.db.WithContext(ctx)
+
+	var count int64
+	var err error
+	switch role {
+	case models.RoleApplicant:
+		err = db.Model(&models.Applicant{}).Where("id = ? AND blocked_at IS NOT NULL", userId).Count(&count).Error
+	case models.RoleCompany:
+		err = db.Model(&models.Company{}).Where("id = ? AND blocked_at IS NOT NULL", userId).Count(&count).Error
+	case models.RoleCompanyMember:
+		err = db.Model(&models.CompanyMember{}).
+			Joins("JOIN companies ON companies.id = company_members.company_id").
+			Where("company_members.id = ? AND companies.blocked_at IS NOT NULL", userId).
+			Count(&count).Error
+	}
+
+	return count > 0, err
+}
+
+// Exists проверяет, что аккаунт с ролью role существует и не удалён.
+func (r *adminRepository) Exists(ctx context.Context, userId uint, role string) (bool, error) {
+	var model any
+	switch role {
+	case models.RoleApplicant:
+		model = &models.Applicant{}
+	case models.RoleCompany:
+		model = &models.Company{}
+	case models.RoleCompanyMember:
+		model = &models.CompanyMember{}
+	default:
+		return false, nil
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(model).Where("id = ?", userId).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *adminRepository) Stats(ctx context.Context) (*models.PlatformStats, error) {
+	db := r.db.WithContext(ctx)
+	stats := &models.PlatformStats{}
+	weekAgo := time.Now().AddDate(0, 0, -7)
+
+	var applicantSignups, companySignups int64
+	counts := []struct {
+		model any
+		where string
+		args  []any
+		dest  *int64
+	}{
+		{&models.Applicant{}, "", nil, &stats.Applicants},
+		{&models.Applicant{}, "blocked_at IS NOT NULL", nil, &stats.BlockedApplicants},
+		{&models.Applicant{}, "created_at >= ?", []any{weekAgo}, &applicantSignups},
+		{&models.Company{}, "", nil, &stats.Companies},
+		{&models.Company{}, "verified", nil, &stats.VerifiedCompanies},
+		{&models.Company{}, "blocked_at IS NOT NULL", nil, &stats.BlockedCompanies},
+		{&models.Company{}, "created_at >= ?", []any{weekAgo}, &companySignups},
+		{&models.CompanyMember{}, "status = ?", []any{models.MemberActive}, &stats.CompanyMembers},
+	}
+	for _, c := range counts {
+		query := db.Model(c.model)
+		if c.where != "" {
+			query = query.Where(c.where, c.args...)
+		}
+		if err := query.Count(c.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+	stats.SignupsLastWeek = applicantSignups + companySignups
+
+	var err error
+	if stats.Vacancies, err = countByStatus(db, &models.Vacancy{}); err != nil {
+		return nil, err
+	}
+	if stats.Applications, err = countByStatus(db, &models.Application{}); err != nil {
+		return nil, err
+	}
+	if stats.Reviews, err = countByStatus(db, &models.CompanyReview{}); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// countByStatus считает строки модели по колонке status.
+func countByStatus(db *gorm.DB, model any) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := db.Model(model).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
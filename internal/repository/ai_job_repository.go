@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AIJobRepository interface {
+	Create(job *models.AIJob) error
+	Enqueue(ctx context.Context, tx *gorm.DB, job *models.AIJob) error
+	GetByID(id uint) (*models.AIJob, error)
+	ClaimNext(ctx context.Context) (*models.AIJob, error)
+	MarkSucceeded(ctx context.Context, id uint, result json.RawMessage) error
+	MarkRetry(ctx context.Context, id uint, errText string, runAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, errText string) error
+	RequeueStale(ctx context.Context, startedBefore time.Time) (int64, error)
+}
+
+type aiJobRepository struct {
+	db *gorm.DB
+}
+
+func NewAIJobRepository(db *gorm.DB) AIJobRepository {
+	return &aiJobRepository{db: db}
+}
+
+func (r *aiJobRepository) Create(job *models.AIJob) error {
+	return r.db.Create(job).Error
+}
+
+// Enqueue ставит задачу в очередь. tx — транзакция изменения, ради
+// которого задача создаётся; если nil, задача пишется отдельно.
+func (r *aiJobRepository) Enqueue(ctx context.Context, tx *gorm.DB, job *models.AIJob) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Create(job).Error
+}
+
+func (r *aiJobRepository) GetByID(id uint) (*models.AIJob, error) {
+	var job models.AIJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimNext забирает самую раннюю готовую к запуску задачу и переводит её
+// в running. SKIP LOCKED позволяет нескольким воркерам (и инстансам)
+// разбирать очередь без двойной обработки. Если задач нет, возвращает nil.
+func (r *aiJobRepository) ClaimNext(ctx context.Context) (*models.AIJob, error) {
+	var job models.AIJob
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", models.AIJobQueued, time.Now()).
+			Order("run_at, id").
+			Take(&job).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = models.AIJobRunning
+		job.Attempts++
+		job.StartedAt = &now
+
+		return tx.Model(&job).Updates(map[string]any{
+			"status":     job.Status,
+			"attempts":   job.Attempts,
+			"started_at": job.StartedAt,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *aiJobRepository) MarkSucceeded(ctx context.Context, id uint, result json.RawMessage) error {
+	return r.db.WithContext(ctx).Model(&models.AIJob{}).Where("id = ?", id).Updates(map[string]any{
+		"status":      models.AIJobSucceeded,
+		"result":      result,
+		"error":       "",
+		"finished_at": time.Now(),
+	}).Error
+}
+
+func (r *aiJobRepository) MarkRetry(ctx context.Context, id uint, errText string, runAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.AIJob{}).Where("id = ?", id).Updates(map[string]any{
+		"status": models.AIJobQueued,
+		"error":  errText,
+		"run_at": runAt,
+	}).Error
+}
+
+func (r *aiJobRepository) MarkFailed(ctx context.Context, id uint, errText string) error {
+	return r.db.WithContext(ctx).Model(&models.AIJob{}).Where("id = ?", id).Updates(map[string]any{
+		"status":      models.AIJobFailed,
+		"error":       errText,
+		"finished_at": time.Now(),
+	}).Error
+}
+
+// RequeueStale возвращает в очередь задачи, зависшие в running (например,
+// после падения процесса посреди обработки).
+func (r *aiJobRepository) RequeueStale(ctx context.Context, startedBefore time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&models.AIJob{}).
+		Where("status = ? AND started_at < ?", models.AIJobRunning, startedBefore).
+		Updates(map[string]any{
+			"status": models.AIJobQueued,
+			"run_at": time.Now(),
+		})
+
+	return res.RowsAffected, res.Error
+}
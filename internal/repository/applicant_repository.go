@@ -76,6 +76,7 @@ func (r *ApplicantRepository) GetByID(id uint) (*dto.ApplicantResponse, error) {
 		Phone:      applicant.Phone,
 		Role:       applicant.Role,
 		IsVerified: applicant.IsVerified,
+		Locale:     applicant.Locale,
 	}
 
 	r.logger.Info("соискатель успешно получен",
@@ -156,3 +157,27 @@ func (r *ApplicantRepository) CheckEmail(ctx context.Context, tx *gorm.DB, email
 
 	return user, true, nil
 }
+
+func (r *ApplicantRepository) SetVerified(ctx context.Context, tx *gorm.DB, id uint) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&models.Applicant{}).Where("id = ?", id).Update("is_verified", true).Error
+}
+
+func (r *ApplicantRepository) UpdatePassword(ctx context.Context, tx *gorm.DB, id uint, hashedPassword string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&models.Applicant{}).Where("id = ?", id).Update("password", hashedPassword)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
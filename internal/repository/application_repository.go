@@ -1,15 +1,32 @@
 package repository
 
 import (
+	"context"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ApplicationTxHook выполняется в транзакции, записавшей изменение отклика,
+// чтобы связанные записи (например, письма в outbox) сохранились вместе с ним.
+type ApplicationTxHook func(tx *gorm.DB, app *models.Application, change *models.ApplicationStatusChange) error
+
 type ApplicationRepository interface {
-	Create(*models.Application) error
+	Create(application *models.Application, applicantId uint, hook ApplicationTxHook) error
+	GetByID(id uint) (*models.Application, error)
 	Applications(uint, models.ApplicationFilter) ([]models.Application, error)
-	AcceptApplication(appId uint) error
-	RejectApplication(appId uint) error
+	ChangeStatus(
+		appId uint,
+		status models.ApplicationStatus,
+		actorId uint,
+		actorRole string,
+		comment string,
+		hook ApplicationTxHook,
+	) (*models.Application, error)
+	History(appId uint) ([]models.ApplicationStatusChange, error)
+	SaveMatch(ctx context.Context, app *models.Application) error
 }
 
 type applicationRepository struct {
@@ -20,26 +37,81 @@ func NewApplicationRepository(db *gorm.DB) ApplicationRepository {
 	return &applicationRepository{db: db}
 }
 
-func (r *applicationRepository) RejectApplication(appId uint) error {
-	if err := r.db.Model(&models.Application{}).
-		Where("id = ? AND status <> ?", appId, models.StatusRejected).
-		Update("status", models.StatusRejected).
-		Error; err != nil {
-		return err
+// ChangeStatus переводит отклик в новый статус, если переход разрешён,
+// и записывает изменение в историю в той же транзакции.
+func (r *applicationRepository) ChangeStatus(
+	appId uint,
+	status models.ApplicationStatus,
+	actorId uint,
+	actorRole string,
+	comment string,
+	hook ApplicationTxHook,
+) (*models.Application, error) {
+	var app models.Application
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&app, appId).Error; err != nil {
+			return err
+		}
+
+		change, err := changeApplicationStatus(tx, &app, status, actorId, actorRole, comment)
+		if err != nil {
+			return err
+		}
+
+		if hook != nil {
+			return hook(tx, &app, change)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// changeApplicationStatus переводит заблокированный в tx отклик в новый
+// статус и пишет запись в историю.
+func changeApplicationStatus(
+	tx *gorm.DB,
+	app *models.Application,
+	status models.ApplicationStatus,
+	actorId uint,
+	actorRole string,
+	comment string,
+) (*models.ApplicationStatusChange, error) {
+	if !app.Status.CanTransitionTo(status) {
+		return nil, constants.ErrInvalidStatusTransition
+	}
+
+	change := models.ApplicationStatusChange{
+		ApplicationID: app.ID,
+		FromStatus:    app.Status,
+		ToStatus:      status,
+		ChangedByID:   actorId,
+		ChangedByRole: actorRole,
+		Comment:       comment,
+	}
+
+	if err := tx.Model(app).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(&change).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &change, nil
 }
 
-func (r *applicationRepository) AcceptApplication(appId uint) error {
-	if err := r.db.Model(&models.Application{}).
-		Where("id = ? AND status <> ?", appId, models.StatusAccepted).
-		Update("status", models.StatusAccepted).
-		Error; err != nil {
-		return err
+func (r *applicationRepository) History(appId uint) ([]models.ApplicationStatusChange, error) {
+	var changes []models.ApplicationStatusChange
+	if err := r.db.Where("application_id = ?", appId).Order("created_at, id").Find(&changes).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return changes, nil
 }
 
 func (r *applicationRepository) Applications(id uint, filter models.ApplicationFilter) ([]models.Application, error) {
@@ -48,7 +120,13 @@ func (r *applicationRepository) Applications(id uint, filter models.ApplicationF
 		Joins("JOIN vacancies ON vacancies.id = applications.vacancy_id ").
 		Where("vacancies.company_id = ?", id)
 	if filter.Status != nil {
-		query = query.Where("status = ?", *filter.Status)
+		query = query.Where("applications.status = ?", *filter.Status)
+	}
+	switch filter.Sort {
+	case models.ApplicationSortMatch:
+		query = query.Order("applications.match_score DESC NULLS LAST").Order("applications.created_at DESC")
+	case models.ApplicationSortDate:
+		query = query.Order("applications.created_at DESC")
 	}
 	if err := query.Find(&apps).Error; err != nil {
 		return nil, err
@@ -57,6 +135,44 @@ func (r *applicationRepository) Applications(id uint, filter models.ApplicationF
 	return apps, nil
 }
 
-func (r *applicationRepository) Create(application *models.Application) error {
-	return r.db.Create(&application).Error
+func (r *applicationRepository) GetByID(id uint) (*models.Application, error) {
+	var app models.Application
+	if err := r.db.Preload("Vacancy").Preload("Resume").First(&app, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// Create сохраняет отклик вместе с первой записью истории статусов.
+func (r *applicationRepository) Create(application *models.Application, applicantId uint, hook ApplicationTxHook) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(application).Error; err != nil {
+			return err
+		}
+
+		change := models.ApplicationStatusChange{
+			ApplicationID: application.ID,
+			ToStatus:      application.Status,
+			ChangedByID:   applicantId,
+			ChangedByRole: models.RoleApplicant,
+		}
+
+		if err := tx.Create(&change).Error; err != nil {
+			return err
+		}
+
+		if hook != nil {
+			return hook(tx, application, &change)
+		}
+		return nil
+	})
+}
+
+// SaveMatch записывает только оценку соответствия, не затирая статус,
+// который мог измениться, пока модель думала.
+func (r *applicationRepository) SaveMatch(ctx context.Context, app *models.Application) error {
+	return r.db.WithContext(ctx).Model(app).
+		Select("match_score", "matched_requirements", "missing_requirements", "match_justification").
+		Updates(app).Error
 }
@@ -1,14 +1,22 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CompanyRepository interface {
 	Get(uint) (*models.Company, error)
 	List() ([]models.Company, error)
 	Create(company *models.Company) error
+	GetByEmail(ctx context.Context, tx *gorm.DB, email string) (*models.Company, error)
+	CheckEmail(ctx context.Context, tx *gorm.DB, email string) (bool, error)
+	Update(id uint, apply func(*models.Company) error) (*models.Company, error)
+	Delete(id uint) error
 }
 
 type companyRepository struct {
@@ -40,3 +48,78 @@ func (r *companyRepository) List() ([]models.Company, error) {
 func (r *companyRepository) Create(company *models.Company) error {
 	return r.db.Create(&company).Error
 }
+
+func (r *companyRepository) GetByEmail(ctx context.Context, tx *gorm.DB, email string) (*models.Company, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var company models.Company
+	if err := tx.WithContext(ctx).Where("email = ?", email).Take(&company).Error; err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
+
+func (r *companyRepository) CheckEmail(ctx context.Context, tx *gorm.DB, email string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&models.Company{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Update применяет apply к заблокированной компании и сохраняет её.
+func (r *companyRepository) Update(id uint, apply func(*models.Company) error) (*models.Company, error) {
+	var company models.Company
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&company, id).Error; err != nil {
+			return err
+		}
+		if err := apply(&company); err != nil {
+			return err
+		}
+
+		return tx.Save(&company).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
+
+// Delete мягко удаляет компанию. Вакансии удалить нельзя (OnDelete:RESTRICT),
+// и на них есть отклики, поэтому они остаются, но закрываются:
+// открытые переходят в closed, черновики — в archived.
+func (r *companyRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var company models.Company
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&company, id).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&models.Vacancy{}).
+			Where("company_id = ? AND status IN ?", id, []models.VacancyStatus{models.VacancyPublished, models.VacancyPaused}).
+			Updates(map[string]any{"status": models.VacancyClosed, "closed_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Vacancy{}).
+			Where("company_id = ? AND status = ?", id, models.VacancyDraft).
+			Update("status", models.VacancyArchived).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&company).Error
+	})
+}
@@ -0,0 +1,377 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InterviewTxHook выполняется в транзакции изменения собеседования,
+// чтобы уведомления сохранились вместе с ним.
+type InterviewTxHook func(tx *gorm.DB, interview *models.Interview) error
+
+// slotBooked — условие «на слоте есть назначенное собеседование».
+const slotBooked = `EXISTS (SELECT 1 FROM interviews WHERE interviews.slot_id = interview_slots.id ` +
+	`AND interviews.status = 'scheduled' AND interviews.deleted_at IS NULL)`
+
+type InterviewRepository interface {
+	CreateSlot(ctx context.Context, slot *models.InterviewSlot) error
+	Slots(ctx context.Context, vacancyId uint, filter models.InterviewSlotFilter) ([]models.InterviewSlot, error)
+	DeleteSlot(ctx context.Context, vacancyId uint, slotId uint) error
+	Active(ctx context.Context, appId uint) (*models.Interview, error)
+	Book(ctx context.Context, interview *models.Interview, slotId uint, hook InterviewTxHook, onStatus ApplicationTxHook) error
+	Reschedule(ctx context.Context, appId uint, slotId uint, hook InterviewTxHook) (*models.Interview, error)
+	Cancel(ctx context.Context, tx *gorm.DB, appId uint, actorRole string, reason string, hook InterviewTxHook) (*models.Interview, error)
+}
+
+type interviewRepository struct {
+	db *gorm.DB
+}
+
+func NewInterviewRepository(db *gorm.DB) InterviewRepository {
+	return &interviewRepository{db: db}
+}
+
+// CreateSlot сохраняет слот, если он не пересекается с другими слотами
+// компании. Строка компании блокируется, чтобы параллельные запросы
+// не создали пересекающиеся слоты.
+func (r *interviewRepository) CreateSlot(ctx context.Context, slot *models.InterviewSlot) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Company{}, slot.CompanyID).Error; err != nil {
+			return err
+		}
+
+		var overlapping int64
+		err := tx.Model(&models.InterviewSlot{}).
+			Where("company_id = ? AND starts_at < ? AND ends_at > ?", slot.CompanyID, slot.EndsAt, slot.StartsAt).
+			Count(&overlapping).Error
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return constants.ErrSlotOverlaps
+		}
+
+		return tx.Create(slot).Error
+	})
+}
+
+func (r *interviewRepository) Slots(
+	ctx context.Context,
+	vacancyId uint,
+	filter models.InterviewSlotFilter,
+) ([]models.InterviewSlot, error) {
+	from := time.Now()
+	if filter.From != nil {
+		from = *filter.From
+	}
+
+	query := r.db.WithContext(ctx).
+		Select("interview_slots.*, "+slotBooked+" AS booked").
+		Where("vacancy_id = ? AND starts_at >= ?", vacancyId, from)
+	if filter.OnlyFree {
+		query = query.Where("NOT " + slotBooked)
+	}
+
+	var slots []models.InterviewSlot
+	if err := query.Order("starts_at").Find(&slots).Error; err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+// DeleteSlot удаляет слот вакансии. Занятый слот удалить нельзя:
+// сначала нужно отменить собеседование.
+func (r *interviewRepository) DeleteSlot(ctx context.Context, vacancyId uint, slotId uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		slot, err := lockSlot(tx, vacancyId, slotId)
+		if err != nil {
+			return err
+		}
+
+		booked, err := isSlotBooked(tx, slot.ID)
+		if err != nil {
+			return err
+		}
+		if booked {
+			return constants.ErrSlotBooked
+		}
+
+		return tx.Delete(slot).Error
+	})
+}
+
+func (r *interviewRepository) Active(ctx context.Context, appId uint) (*models.Interview, error) {
+	var interview models.Interview
+	err := r.db.WithContext(ctx).
+		Where("application_id = ? AND status = ?", appId, models.InterviewScheduled).
+		Take(&interview).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrInterviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &interview, nil
+}
+
+// Book назначает собеседование соискателя на свободный слот вакансии
+// отклика. Стороны отклика заполняет вызывающий. Просмотренный отклик
+// переводится в статус interview; об этом в той же транзакции узнаёт onStatus.
+func (r *interviewRepository) Book(
+	ctx context.Context,
+	interview *models.Interview,
+	slotId uint,
+	hook InterviewTxHook,
+	onStatus ApplicationTxHook,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var app models.Application
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&app, interview.ApplicationID).Error; err != nil {
+			return err
+		}
+		if app.Status != models.StatusReviewed && app.Status != models.StatusInterview {
+			return constants.ErrInterviewNotAllowed
+		}
+
+		var active int64
+		err := tx.Model(&models.Interview{}).
+			Where("application_id = ? AND status = ?", app.ID, models.InterviewScheduled).
+			Count(&active).Error
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return constants.ErrInterviewExists
+		}
+
+		slot, err := lockFreeSlot(tx, app.VacancyID, slotId)
+		if err != nil {
+			return err
+		}
+
+		interview.VacancyID = app.VacancyID
+		interview.Status = models.InterviewScheduled
+		setInterviewSlot(interview, slot)
+
+		if err := checkApplicantConflict(tx, interview); err != nil {
+			return err
+		}
+
+		if err := tx.Create(interview).Error; err != nil {
+			return err
+		}
+
+		if app.Status == models.StatusReviewed {
+			change, err := changeApplicationStatus(tx, &app, models.StatusInterview, interview.ApplicantID, models.RoleApplicant, "")
+			if err != nil {
+				return err
+			}
+			if onStatus != nil {
+				if err := onStatus(tx, &app, change); err != nil {
+					return err
+				}
+			}
+		}
+
+		if hook != nil {
+			return hook(tx, interview)
+		}
+		return nil
+	})
+}
+
+// Reschedule переносит назначенное собеседование на другой свободный слот
+// той же вакансии.
+func (r *interviewRepository) Reschedule(
+	ctx context.Context,
+	appId uint,
+	slotId uint,
+	hook InterviewTxHook,
+) (*models.Interview, error) {
+	var interview *models.Interview
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		interview, err = lockActiveInterview(tx, appId)
+		if err != nil {
+			return err
+		}
+
+		slot, err := lockFreeSlot(tx, interview.VacancyID, slotId)
+		if err != nil {
+			return err
+		}
+
+		setInterviewSlot(interview, slot)
+		interview.Sequence++
+
+		if err := checkApplicantConflict(tx, interview); err != nil {
+			return err
+		}
+
+		err = tx.Model(interview).Updates(map[string]any{
+			"slot_id":   interview.SlotID,
+			"starts_at": interview.StartsAt,
+			"ends_at":   interview.EndsAt,
+			"location":  interview.Location,
+			"sequence":  interview.Sequence,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if hook != nil {
+			return hook(tx, interview)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return interview, nil
+}
+
+// Cancel отменяет назначенное собеседование; слот снова становится
+// свободным. Если tx равен nil, открывается своя транзакция.
+func (r *interviewRepository) Cancel(
+	ctx context.Context,
+	tx *gorm.DB,
+	appId uint,
+	actorRole string,
+	reason string,
+	hook InterviewTxHook,
+) (*models.Interview, error) {
+	if tx == nil {
+		var interview *models.Interview
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			interview, err = r.Cancel(ctx, tx, appId, actorRole, reason, hook)
+			return err
+		})
+		return interview, err
+	}
+
+	interview, err := lockActiveInterview(tx, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	interview.Status = models.InterviewCancelled
+	interview.CancelledByRole = actorRole
+	interview.CancelReason = reason
+	interview.CancelledAt = &now
+	interview.Sequence++
+
+	err = tx.Model(interview).Updates(map[string]any{
+		"status":            interview.Status,
+		"cancelled_by_role": interview.CancelledByRole,
+		"cancel_reason":     interview.CancelReason,
+		"cancelled_at":      interview.CancelledAt,
+		"sequence":          interview.Sequence,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if hook != nil {
+		if err := hook(tx, interview); err != nil {
+			return nil, err
+		}
+	}
+
+	return interview, nil
+}
+
+func lockSlot(tx *gorm.DB, vacancyId uint, slotId uint) (*models.InterviewSlot, error) {
+	var slot models.InterviewSlot
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND vacancy_id = ?", slotId, vacancyId).
+		Take(&slot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrSlotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &slot, nil
+}
+
+// lockFreeSlot блокирует слот вакансии и проверяет, что он в будущем и не занят.
+func lockFreeSlot(tx *gorm.DB, vacancyId uint, slotId uint) (*models.InterviewSlot, error) {
+	slot, err := lockSlot(tx, vacancyId, slotId)
+	if err != nil {
+		return nil, err
+	}
+	if !slot.StartsAt.After(time.Now()) {
+		return nil, constants.ErrSlotUnavailable
+	}
+
+	booked, err := isSlotBooked(tx, slot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if booked {
+		return nil, constants.ErrSlotUnavailable
+	}
+
+	return slot, nil
+}
+
+func isSlotBooked(tx *gorm.DB, slotId uint) (bool, error) {
+	var count int64
+	err := tx.Model(&models.Interview{}).
+		Where("slot_id = ? AND status = ?", slotId, models.InterviewScheduled).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func lockActiveInterview(tx *gorm.DB, appId uint) (*models.Interview, error) {
+	var interview models.Interview
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("application_id = ? AND status = ?", appId, models.InterviewScheduled).
+		Take(&interview).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrInterviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &interview, nil
+}
+
+// checkApplicantConflict не даёт соискателю два собеседования в одно время.
+func checkApplicantConflict(tx *gorm.DB, interview *models.Interview) error {
+	var count int64
+	err := tx.Model(&models.Interview{}).
+		Where("applicant_id = ? AND status = ? AND id <> ? AND starts_at < ? AND ends_at > ?",
+			interview.ApplicantID, models.InterviewScheduled, interview.ID, interview.EndsAt, interview.StartsAt).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return constants.ErrInterviewConflict
+	}
+
+	return nil
+}
+
+func setInterviewSlot(interview *models.Interview, slot *models.InterviewSlot) {
+	interview.SlotID = slot.ID
+	interview.StartsAt = slot.StartsAt
+	interview.EndsAt = slot.EndsAt
+	interview.Location = slot.Location
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MemberRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, member *models.CompanyMember) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (models.CompanyMember, error)
+	GetByEmail(ctx context.Context, tx *gorm.DB, email string) (models.CompanyMember, error)
+	List(ctx context.Context, companyId uint) ([]models.CompanyMember, error)
+	UpdateRole(ctx context.Context, tx *gorm.DB, id uint, role models.MemberRole) error
+	Activate(ctx context.Context, tx *gorm.DB, id uint, name string, password string) (models.CompanyMember, error)
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+	AssignVacancy(ctx context.Context, vacancyId uint, memberId uint) error
+	UnassignVacancy(ctx context.Context, vacancyId uint, memberId uint) error
+	IsAssigned(ctx context.Context, vacancyId uint, memberId uint) (bool, error)
+	ListVacancyTeam(ctx context.Context, tx *gorm.DB, companyId uint, vacancyId uint) ([]models.CompanyMember, error)
+}
+
+type memberRepository struct {
+	db *gorm.DB
+}
+
+func NewMemberRepository(db *gorm.DB) MemberRepository {
+	return &memberRepository{db: db}
+}
+
+func (r *memberRepository) Create(ctx context.Context, tx *gorm.DB, member *models.CompanyMember) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Create(member).Error
+}
+
+func (r *memberRepository) GetByID(ctx context.Context, tx *gorm.DB, id uint) (models.CompanyMember, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var member models.CompanyMember
+	err := tx.WithContext(ctx).First(&member, id).Error
+	return member, err
+}
+
+func (r *memberRepository) GetByEmail(ctx context.Context, tx *gorm.DB, email string) (models.CompanyMember, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var member models.CompanyMember
+	err := tx.WithContext(ctx).Where("email = ?", email).Take(&member).Error
+	return member, err
+}
+
+// List возвращает сотрудников компании вместе с назначенными вакансиями.
+func (r *memberRepository) List(ctx context.Context, companyId uint) ([]models.CompanyMember, error) {
+	db := r.db.WithContext(ctx)
+
+	var members []models.CompanyMember
+	if err := db.Where("company_id = ?", companyId).Order("id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	ids := make([]uint, len(members))
+	for i := range members {
+		ids[i] = members[i].ID
+	}
+
+	var assignments []models.VacancyRecruiter
+	if err := db.Where("member_id IN ?", ids).Order("vacancy_id").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	vacancies := make(map[uint][]uint, len(members))
+	for _, assignment := range assignments {
+		vacancies[assignment.MemberID] = append(vacancies[assignment.MemberID], assignment.VacancyID)
+	}
+	for i := range members {
+		members[i].VacancyIDs = vacancies[members[i].ID]
+	}
+
+	return members, nil
+}
+
+func (r *memberRepository) UpdateRole(ctx context.Context, tx *gorm.DB, id uint, role models.MemberRole) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&models.CompanyMember{}).Where("id = ?", id).Update("role", role).Error
+}
+
+// Activate завершает приглашение: сотрудник задаёт имя и пароль.
+// Уже принятое приглашение повторно не активируется.
+func (r *memberRepository) Activate(
+	ctx context.Context,
+	tx *gorm.DB,
+	id uint,
+	name string,
+	password string,
+) (models.CompanyMember, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var member models.CompanyMember
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ?", models.MemberInvited).
+		First(&member, id).Error
+	if err != nil {
+		return models.CompanyMember{}, err
+	}
+
+	err = tx.WithContext(ctx).Model(&member).Updates(map[string]any{
+		"name":      name,
+		"password":  password,
+		"status":    models.MemberActive,
+		"joined_at": time.Now(),
+	}).Error
+	return member, err
+}
+
+// Delete мягко удаляет сотрудника и снимает его со всех вакансий.
+func (r *memberRepository) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	tx = tx.WithContext(ctx)
+	if err := tx.Where("member_id = ?", id).Delete(&models.VacancyRecruiter{}).Error; err != nil {
+		return err
+	}
+
+	result := tx.Delete(&models.CompanyMember{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *memberRepository) AssignVacancy(ctx context.Context, vacancyId uint, memberId uint) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.VacancyRecruiter{VacancyID: vacancyId, MemberID: memberId}).Error
+}
+
+func (r *memberRepository) UnassignVacancy(ctx context.Context, vacancyId uint, memberId uint) error {
+	return r.db.WithContext(ctx).
+		Where("vacancy_id = ? AND member_id = ?", vacancyId, memberId).
+		Delete(&models.VacancyRecruiter{}).Error
+}
+
+func (r *memberRepository) IsAssigned(ctx context.Context, vacancyId uint, memberId uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.VacancyRecruiter{}).
+		Where("vacancy_id = ? AND member_id = ?", vacancyId, memberId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListVacancyTeam возвращает активных сотрудников, которые ведут отклики
+// на вакансию: владельцев, администраторов и назначенных рекрутеров.
+func (r *memberRepository) ListVacancyTeam(
+	ctx context.Context,
+	tx *gorm.DB,
+	companyId uint,
+	vacancyId uint,
+) ([]models.CompanyMember, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	assigned := r.db.Model(&models.VacancyRecruiter{}).Select("member_id").Where("vacancy_id = ?", vacancyId)
+
+	var members []models.CompanyMember
+	err := tx.WithContext(ctx).
+		Where("company_id = ? AND status = ?", companyId, models.MemberActive).
+		Where("role IN ? OR (role = ? AND id IN (?))",
+			[]models.MemberRole{models.MemberOwner, models.MemberAdmin}, models.MemberRecruiter, assigned,
+		).
+		Order("id").
+		Find(&members).Error
+	return members, err
+}
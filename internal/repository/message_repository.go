@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+)
+
+const defaultMessageLimit = 50
+
+type MessageRepository interface {
+	Create(ctx context.Context, message *models.Message) error
+	List(ctx context.Context, appId uint, filter models.MessageFilter) ([]models.Message, error)
+	CountUnread(ctx context.Context, appId uint, readerRole string) (int64, error)
+	MarkRead(ctx context.Context, appId uint, readerRole string, readAt time.Time) (int64, error)
+	UnreadByApplication(ctx context.Context, userId uint, role string) ([]models.MessageUnread, error)
+}
+
+type messageRepository struct {
+	db *gorm.DB
+}
+
+func NewMessageRepository(db *gorm.DB) MessageRepository {
+	return &messageRepository{db: db}
+}
+
+// Create сохраняет сообщение вместе с вложениями.
+func (r *messageRepository) Create(ctx context.Context, message *models.Message) error {
+	return r.db.WithContext(ctx).Create(message).Error
+}
+
+// List возвращает страницу переписки в хронологическом порядке.
+func (r *messageRepository) List(ctx context.Context, appId uint, filter models.MessageFilter) ([]models.Message, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultMessageLimit
+	}
+
+	query := r.db.WithContext(ctx).Preload("Attachments").Where("application_id = ?", appId)
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var messages []models.Message
+	if err := query.Order("id DESC").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+// CountUnread считает сообщения другой стороны, которые readerRole ещё не прочитал.
+func (r *messageRepository) CountUnread(ctx context.Context, appId uint, readerRole string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("application_id = ? AND sender_role <> ? AND read_at IS NULL", appId, readerRole).
+		Count(&count).Error
+
+	return count, err
+}
+
+// MarkRead отмечает прочитанными все сообщения другой стороны.
+func (r *messageRepository) MarkRead(ctx context.Context, appId uint, readerRole string, readAt time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("application_id = ? AND sender_role <> ? AND read_at IS NULL", appId, readerRole).
+		Update("read_at", readAt)
+
+	return res.RowsAffected, res.Error
+}
+
+// UnreadByApplication возвращает переписки пользователя, в которых есть
+// непрочитанные им сообщения.
+func (r *messageRepository) UnreadByApplication(ctx context.Context, userId uint, role string) ([]models.MessageUnread, error) {
+	query := r.db.WithContext(ctx).Model(&models.Message{}).
+		Select("messages.application_id, COUNT(*) AS unread").
+		Joins("JOIN applications ON applications.id = messages.application_id AND applications.deleted_at IS NULL").
+		Where("messages.sender_role <> ? AND messages.read_at IS NULL", role)
+
+	switch role {
+	case models.RoleApplicant:
+		query = query.Joins("JOIN resumes ON resumes.id = applications.resume_id").
+			Where("resumes.applicant_id = ?", userId)
+	case models.RoleCompany:
+		query = query.Joins("JOIN vacancies ON vacancies.id = applications.vacancy_id").
+			Where("vacancies.company_id = ?", userId)
+	default:
+		return nil, nil
+	}
+
+	var unread []models.MessageUnread
+	err := query.Group("messages.application_id").Order("messages.application_id").Scan(&unread).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return unread, nil
+}
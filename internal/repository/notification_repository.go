@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultNotificationLimit = 20
+
+type NotificationRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, notification *models.Notification) error
+	List(ctx context.Context, userID uint, role string, filter models.NotificationFilter) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uint, role string) (int64, error)
+	MarkRead(ctx context.Context, userID uint, role string, id uint) (bool, error)
+	MarkAllRead(ctx context.Context, userID uint, role string) (int64, error)
+	Preferences(ctx context.Context, tx *gorm.DB, userID uint, role string) ([]models.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, tx *gorm.DB, notification *models.Notification) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Create(notification).Error
+}
+
+// List возвращает уведомления получателя, новые — первыми.
+func (r *notificationRepository) List(
+	ctx context.Context,
+	userID uint,
+	role string,
+	filter models.NotificationFilter,
+) ([]models.Notification, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+
+	query := r.db.WithContext(ctx).Where("user_id = ? AND role = ?", userID, role)
+	if filter.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []models.Notification
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(filter.Offset).Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uint, role string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND role = ? AND read_at IS NULL", userID, role).
+		Count(&count).Error
+
+	return count, err
+}
+
+// MarkRead отмечает уведомление прочитанным. Повторная отметка не меняет
+// время прочтения; false — уведомление не найдено у этого получателя.
+func (r *notificationRepository) MarkRead(ctx context.Context, userID uint, role string, id uint) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND role = ?", id, userID, role).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID uint, role string) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND role = ? AND read_at IS NULL", userID, role).
+		Update("read_at", time.Now())
+
+	return res.RowsAffected, res.Error
+}
+
+func (r *notificationRepository) Preferences(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID uint,
+	role string,
+) ([]models.NotificationPreference, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var preferences []models.NotificationPreference
+	if err := tx.WithContext(ctx).Where("user_id = ? AND role = ?", userID, role).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *notificationRepository) SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "role"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "updated_at"}),
+	}).Create(&preferences).Error
+}
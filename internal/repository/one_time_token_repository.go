@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OneTimeTokenRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, token models.OneTimeToken) (models.OneTimeToken, error)
+	FindByHash(ctx context.Context, tx *gorm.DB, hash string, purpose string) (models.OneTimeToken, error)
+	Consume(ctx context.Context, tx *gorm.DB, id uuid.UUID) (bool, error)
+	DeleteByUserID(ctx context.Context, tx *gorm.DB, userID uint, role string, purpose string) error
+	DeleteExpired(ctx context.Context, tx *gorm.DB) (int64, error)
+}
+
+type oneTimeTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeTokenRepository(db *gorm.DB) OneTimeTokenRepository {
+	return &oneTimeTokenRepository{
+		db: db,
+	}
+}
+
+func (r *oneTimeTokenRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	token models.OneTimeToken,
+) (models.OneTimeToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&token).Error; err != nil {
+		return models.OneTimeToken{}, err
+	}
+
+	return token, nil
+}
+
+func (r *oneTimeTokenRepository) FindByHash(
+	ctx context.Context,
+	tx *gorm.DB,
+	hash string,
+	purpose string,
+) (models.OneTimeToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var token models.OneTimeToken
+	if err := tx.WithContext(ctx).Where("token_hash = ? AND purpose = ?", hash, purpose).Take(&token).Error; err != nil {
+		return models.OneTimeToken{}, err
+	}
+
+	return token, nil
+}
+
+// Consume гасит токен. false означает, что токен уже использован
+// или истёк к моменту запроса.
+func (r *oneTimeTokenRepository) Consume(ctx context.Context, tx *gorm.DB, id uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	now := time.Now()
+	result := tx.WithContext(ctx).Model(&models.OneTimeToken{}).
+		Where("id = ? AND consumed_at IS NULL AND expires_at > ?", id, now).
+		Update("consumed_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteByUserID удаляет ранее выданные токены того же назначения:
+// действует только последнее письмо.
+func (r *oneTimeTokenRepository) DeleteByUserID(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID uint,
+	role string,
+	purpose string,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).
+		Where("user_id = ? AND role = ? AND purpose = ?", userID, role, purpose).
+		Delete(&models.OneTimeToken{}).Error
+}
+
+func (r *oneTimeTokenRepository) DeleteExpired(ctx context.Context, tx *gorm.DB) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.OneTimeToken{})
+	return result.RowsAffected, result.Error
+}
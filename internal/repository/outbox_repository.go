@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, tx *gorm.DB, email *models.OutboxEmail) error
+	ClaimNext(ctx context.Context) (*models.OutboxEmail, error)
+	MarkSent(ctx context.Context, id uint) error
+	MarkRetry(ctx context.Context, id uint, errText string, runAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, errText string) error
+	RequeueStale(ctx context.Context, startedBefore time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Enqueue ставит письмо в очередь. tx — транзакция изменения,
+// о котором сообщает письмо.
+func (r *outboxRepository) Enqueue(ctx context.Context, tx *gorm.DB, email *models.OutboxEmail) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	email.Status = models.OutboxEmailPending
+	if email.RunAt.IsZero() {
+		email.RunAt = time.Now()
+	}
+
+	return tx.WithContext(ctx).Create(email).Error
+}
+
+// ClaimNext забирает самое раннее готовое к отправке письмо и переводит
+// его в sending. Если писем нет, возвращает nil.
+func (r *outboxRepository) ClaimNext(ctx context.Context) (*models.OutboxEmail, error) {
+	var email models.OutboxEmail
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", models.OutboxEmailPending, time.Now()).
+			Order("run_at, id").
+			Take(&email).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		email.Status = models.OutboxEmailSending
+		email.Attempts++
+		email.StartedAt = &now
+
+		return tx.Model(&email).Updates(map[string]any{
+			"status":     email.Status,
+			"attempts":   email.Attempts,
+			"started_at": email.StartedAt,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEmail{}).Where("id = ?", id).Updates(map[string]any{
+		"status":  models.OutboxEmailSent,
+		"error":   "",
+		"sent_at": time.Now(),
+	}).Error
+}
+
+func (r *outboxRepository) MarkRetry(ctx context.Context, id uint, errText string, runAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEmail{}).Where("id = ?", id).Updates(map[string]any{
+		"status": models.OutboxEmailPending,
+		"error":  errText,
+		"run_at": runAt,
+	}).Error
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uint, errText string) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEmail{}).Where("id = ?", id).Updates(map[string]any{
+		"status": models.OutboxEmailFailed,
+		"error":  errText,
+	}).Error
+}
+
+// RequeueStale возвращает в очередь письма, зависшие в sending после
+// падения процесса. Такое письмо может уйти дважды — это лучше, чем потерять его.
+func (r *outboxRepository) RequeueStale(ctx context.Context, startedBefore time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&models.OutboxEmail{}).
+		Where("status = ? AND started_at < ?", models.OutboxEmailSending, startedBefore).
+		Updates(map[string]any{
+			"status": models.OutboxEmailPending,
+			"run_at": time.Now(),
+		})
+
+	return res.RowsAffected, res.Error
+}
@@ -5,15 +5,18 @@ import (
 	"time"
 
 	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, token models.RefreshToken) (models.RefreshToken, error)
 	FindByToken(ctx context.Context, tx *gorm.DB, token string) (models.RefreshToken, error)
-	DeleteByUserID(ctx context.Context, tx *gorm.DB, userID string) error
+	DeleteByUserID(ctx context.Context, tx *gorm.DB, userID uint, role string) error
 	DeleteByToken(ctx context.Context, tx *gorm.DB, token string) error
-	DeleteExpired(ctx context.Context, tx *gorm.DB) error
+	DeleteBySessionID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) error
+	MarkRotated(ctx context.Context, tx *gorm.DB, id uuid.UUID) (bool, error)
+	DeleteExpired(ctx context.Context, tx *gorm.DB) (int64, error)
 }
 
 type refreshTokenRepository struct {
@@ -51,19 +54,19 @@ func (r *refreshTokenRepository) FindByToken(ctx context.Context, tx *gorm.DB, t
 	}
 
 	var refreshToken models.RefreshToken
-	if err := tx.WithContext(ctx).Where("token = ?", token).Preload("User").Take(&refreshToken).Error; err != nil {
+	if err := tx.WithContext(ctx).Where("token = ?", token).Take(&refreshToken).Error; err != nil {
 		return models.RefreshToken{}, err
 	}
 
 	return refreshToken, nil
 }
 
-func (r *refreshTokenRepository) DeleteByUserID(ctx context.Context, tx *gorm.DB, userID string) error {
+func (r *refreshTokenRepository) DeleteByUserID(ctx context.Context, tx *gorm.DB, userID uint, role string) error {
 	if tx == nil {
 		tx = r.db
 	}
 
-	if err := tx.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error; err != nil {
+	if err := tx.WithContext(ctx).Where("user_id = ? AND role = ?", userID, role).Delete(&models.RefreshToken{}).Error; err != nil {
 		return err
 	}
 
@@ -82,14 +85,40 @@ func (r *refreshTokenRepository) DeleteByToken(ctx context.Context, tx *gorm.DB,
 	return nil
 }
 
-func (r *refreshTokenRepository) DeleteExpired(ctx context.Context, tx *gorm.DB) error {
+func (r *refreshTokenRepository) DeleteBySessionID(ctx context.Context, tx *gorm.DB, sessionID uuid.UUID) error {
 	if tx == nil {
 		tx = r.db
 	}
 
-	if err := tx.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.RefreshToken{}).Error; err != nil {
+	if err := tx.WithContext(ctx).Where("session_id = ?", sessionID).Delete(&models.RefreshToken{}).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// MarkRotated помечает токен использованным. false означает, что токен
+// уже был использован (например, параллельным запросом).
+func (r *refreshTokenRepository) MarkRotated(ctx context.Context, tx *gorm.DB, id uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL", id).
+		Update("rotated_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *refreshTokenRepository) DeleteExpired(ctx context.Context, tx *gorm.DB) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -2,19 +2,40 @@ package repository
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ResumeTxHook выполняется в транзакции сохранения резюме,
+// чтобы связанные записи (например, уведомления) сохранились вместе с ним.
+type ResumeTxHook func(tx *gorm.DB, resume *models.Resume) error
+
 type ResumeRepository interface {
 	Create(resume *models.Resume) error
 	GetAllResumes() ([]models.Resume, error)
 	GetByID(id uint) (*models.Resume, error)
 	Save(resume *models.Resume) error
-	Update(id uint, resume *models.Resume) error
+	SaveWithVersion(resume *models.Resume, version *models.ResumeVersion, hook ResumeTxHook) error
+	SaveAISuggestion(id uint, suggest func(resume *models.Resume) *models.ResumeVersion, hook ResumeTxHook) (*models.Resume, error)
 	Delete(id uint) error
 	IsResumeExists(id uint) (bool, error)
+	Versions(resumeId uint) ([]models.ResumeVersion, error)
+	Version(resumeId uint, number int) (*models.ResumeVersion, error)
+	LatestVersion(resumeId uint, source models.ResumeVersionSource) (*models.ResumeVersion, error)
+	ImportLegacySkills() (int64, error)
+}
+
+// resumeSections — связи резюме со структурированными разделами.
+var resumeSections = []string{"WorkExperience", "Education", "SkillList", "Languages"}
+
+func preloadResumeSections(db *gorm.DB) *gorm.DB {
+	for _, section := range resumeSections {
+		db = db.Preload(section)
+	}
+	return db
 }
 
 type gormResumeRepository struct {
@@ -37,7 +58,12 @@ func (r *gormResumeRepository) Create(resume *models.Resume) error {
 		slog.String("position", resume.Position),
 	)
 
-	err := r.db.Create(resume).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(resume).Error; err != nil {
+			return err
+		}
+		return createResumeVersion(tx, models.NewResumeVersion(resume, models.ResumeVersionCreated))
+	})
 
 	if err != nil {
 		r.logger.Error("db error",
@@ -59,7 +85,7 @@ func (r *gormResumeRepository) GetAllResumes() ([]models.Resume, error) {
 
 	var resumes []models.Resume
 
-	if err := r.db.Find(&resumes).Error; err != nil {
+	if err := preloadResumeSections(r.db).Find(&resumes).Error; err != nil {
 		r.logger.Error("db error",
 			slog.String("op", op),
 			slog.Any("error", err),
@@ -79,7 +105,7 @@ func (r *gormResumeRepository) GetByID(id uint) (*models.Resume, error) {
 
 	var resume models.Resume
 
-	if err := r.db.First(&resume, id).Error; err != nil {
+	if err := preloadResumeSections(r.db).First(&resume, id).Error; err != nil {
 		r.logger.Error("db error",
 			slog.String("op", op),
 			slog.Any("error", err),
@@ -95,16 +121,45 @@ func (r *gormResumeRepository) Save(resume *models.Resume) error {
 	return r.db.Save(resume).Error
 }
 
-func (r *gormResumeRepository) Update(id uint, resume *models.Resume) error {
-	op := "repo.resume.update"
+// SaveWithVersion сохраняет резюме и добавляет версию в одной транзакции.
+// Поля version заполняет вызывающий: для ИИ-версии это предложение модели,
+// а не текущее состояние резюме.
+func (r *gormResumeRepository) SaveWithVersion(resume *models.Resume, version *models.ResumeVersion, hook ResumeTxHook) error {
+	op := "repo.resume.save_with_version"
 
 	r.logger.Debug("db call",
 		slog.String("op", op),
+		slog.Uint64("resume_id", uint64(resume.ID)),
+		slog.String("source", string(version.Source)),
 	)
 
-	if err := r.db.Model(&models.Resume{}).Where("id = ?", id).Updates(resume).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// UPDATE блокирует строку резюме до конца транзакции, поэтому
+		// номера версий одного резюме выдаются последовательно.
+		if err := tx.Omit(clause.Associations).Save(resume).Error; err != nil {
+			return err
+		}
+		// Разделы заменяются целиком: записи, которых больше нет в резюме,
+		// удаляются, а не отвязываются.
+		for _, section := range resumeSections {
+			if err := tx.Model(resume).Association(section).Unscoped().Replace(sectionValue(resume, section)); err != nil {
+				return err
+			}
+		}
+		version.ResumeID = resume.ID
+		if err := createResumeVersion(tx, version); err != nil {
+			return err
+		}
+		if hook != nil {
+			return hook(tx, resume)
+		}
+		return nil
+	})
+
+	if err != nil {
 		r.logger.Error("db error",
 			slog.String("op", op),
+			slog.Uint64("resume_id", uint64(resume.ID)),
 			slog.Any("error", err),
 		)
 		return err
@@ -113,6 +168,84 @@ func (r *gormResumeRepository) Update(id uint, resume *models.Resume) error {
 	return nil
 }
 
+// SaveAISuggestion блокирует и перечитывает резюме, передаёт его в suggest
+// и сохраняет только ai_improved, ai_score и версию, которую вернул suggest.
+// Ответа модели ждут долго, и правки, сделанные за это время, не теряются.
+func (r *gormResumeRepository) SaveAISuggestion(
+	id uint,
+	suggest func(resume *models.Resume) *models.ResumeVersion,
+	hook ResumeTxHook,
+) (*models.Resume, error) {
+	op := "repo.resume.save_ai_suggestion"
+
+	r.logger.Debug("db call",
+		slog.String("op", op),
+		slog.Uint64("resume_id", uint64(id)),
+	)
+
+	var resume models.Resume
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Resume{}, id).Error; err != nil {
+			return err
+		}
+		if err := preloadResumeSections(tx).First(&resume, id).Error; err != nil {
+			return err
+		}
+
+		version := suggest(&resume)
+		if err := tx.Model(&resume).Updates(map[string]any{
+			"ai_improved": resume.AIImproved,
+			"ai_score":    resume.AIScore,
+		}).Error; err != nil {
+			return err
+		}
+
+		version.ResumeID = resume.ID
+		if err := createResumeVersion(tx, version); err != nil {
+			return err
+		}
+		if hook != nil {
+			return hook(tx, &resume)
+		}
+		return nil
+	})
+
+	if err != nil {
+		r.logger.Error("db error",
+			slog.String("op", op),
+			slog.Uint64("resume_id", uint64(id)),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	return &resume, nil
+}
+
+func sectionValue(resume *models.Resume, section string) any {
+	switch section {
+	case "WorkExperience":
+		return resume.WorkExperience
+	case "Education":
+		return resume.Education
+	case "SkillList":
+		return resume.SkillList
+	default:
+		return resume.Languages
+	}
+}
+
+func createResumeVersion(tx *gorm.DB, version *models.ResumeVersion) error {
+	if err := tx.Model(&models.ResumeVersion{}).
+		Where("resume_id = ?", version.ResumeID).
+		Select("COALESCE(MAX(number), 0) + 1").
+		Scan(&version.Number).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(version).Error
+}
+
 func (r *gormResumeRepository) Delete(id uint) error {
 	op := "repo.resume.delete"
 
@@ -141,3 +274,73 @@ func (r *gormResumeRepository) IsResumeExists(id uint) (bool, error) {
 
 	return count > 0, nil
 }
+
+func (r *gormResumeRepository) Versions(resumeId uint) ([]models.ResumeVersion, error) {
+	var versions []models.ResumeVersion
+	if err := r.db.Where("resume_id = ?", resumeId).Order("number DESC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *gormResumeRepository) Version(resumeId uint, number int) (*models.ResumeVersion, error) {
+	var version models.ResumeVersion
+	if err := r.db.Where("resume_id = ? AND number = ?", resumeId, number).First(&version).Error; err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+func (r *gormResumeRepository) LatestVersion(resumeId uint, source models.ResumeVersionSource) (*models.ResumeVersion, error) {
+	var version models.ResumeVersion
+	if err := r.db.Where("resume_id = ? AND source = ?", resumeId, source).Order("number DESC").First(&version).Error; err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// ImportLegacySkills раскладывает текстовое поле skills (через запятую)
+// в структурированные навыки без уровня. Трогает только резюме, у которых
+// структурированных навыков ещё нет; текст skills остаётся как есть.
+// Перенос выполняется один раз: потом пользователь может удалить навыки,
+// и они не должны вернуться при следующем запуске.
+func (r *gormResumeRepository) ImportLegacySkills() (int64, error) {
+	var imported int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Несколько экземпляров, запущенных одновременно, ждут друг друга.
+		err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", models.DataMigrationLegacySkills).Error
+		if err != nil {
+			return err
+		}
+
+		var done int64
+		err = tx.Model(&models.DataMigration{}).
+			Where("name = ?", models.DataMigrationLegacySkills).
+			Count(&done).Error
+		if err != nil || done > 0 {
+			return err
+		}
+
+		result := tx.Exec(`
+			INSERT INTO resume_skills (resume_id, name, level)
+			SELECT resumes.id, left(trim(skill), 100), ''
+			FROM resumes
+			CROSS JOIN LATERAL unnest(string_to_array(resumes.skills, ',')) AS skill
+			WHERE resumes.deleted_at IS NULL
+				AND trim(skill) <> ''
+				AND NOT EXISTS (SELECT 1 FROM resume_skills WHERE resume_skills.resume_id = resumes.id)
+		`)
+		if result.Error != nil {
+			return result.Error
+		}
+		imported = result.RowsAffected
+
+		return tx.Create(&models.DataMigration{Name: models.DataMigrationLegacySkills, AppliedAt: time.Now()}).Error
+	})
+
+	return imported, err
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultReviewLimit = 20
+
+// appliedToCompany — у соискателя есть отклик на вакансию компании.
+const appliedToCompany = `SELECT EXISTS (
+	SELECT 1 FROM applications
+	JOIN vacancies ON vacancies.id = applications.vacancy_id
+	JOIN resumes ON resumes.id = applications.resume_id
+	WHERE vacancies.company_id = ? AND resumes.applicant_id = ? AND applications.deleted_at IS NULL
+)`
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.CompanyReview) error
+	List(ctx context.Context, companyId *uint, filter models.ReviewFilter) (*models.ReviewList, error)
+	Reply(ctx context.Context, companyId uint, reviewId uint, reply string) (*models.CompanyReview, error)
+	Moderate(ctx context.Context, reviewId uint, status models.ReviewStatus, comment string) (*models.CompanyReview, error)
+	Delete(ctx context.Context, applicantId uint, reviewId uint) error
+}
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// Create сохраняет отзыв, если соискатель откликался в компанию и ещё
+// не оставлял отзыв. Строка компании блокируется, чтобы два параллельных
+// запроса не создали два отзыва.
+func (r *reviewRepository) Create(ctx context.Context, review *models.CompanyReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Company{}, review.CompanyID).Error; err != nil {
+			return err
+		}
+
+		var applied bool
+		if err := tx.Raw(appliedToCompany, review.CompanyID, review.ApplicantID).Scan(&applied).Error; err != nil {
+			return err
+		}
+		if !applied {
+			return constants.ErrReviewNotAllowed
+		}
+
+		var existing int64
+		err := tx.Model(&models.CompanyReview{}).
+			Where("company_id = ? AND applicant_id = ?", review.CompanyID, review.ApplicantID).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return constants.ErrReviewExists
+		}
+
+		return tx.Create(review).Error
+	})
+}
+
+// List возвращает отзывы компании (или всех компаний, если companyId
+// пуст) в статусе filter.Status.
+func (r *reviewRepository) List(ctx context.Context, companyId *uint, filter models.ReviewFilter) (*models.ReviewList, error) {
+	query := r.db.WithContext(ctx).Model(&models.CompanyReview{})
+	if companyId != nil {
+		query = query.Where("company_id = ?", *companyId)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	direction := "DESC"
+	if filter.Order == "asc" {
+		direction = "ASC"
+	}
+	order := "created_at " + direction + ", id " + direction
+	if filter.Sort == models.ReviewSortRating {
+		order = "rating " + direction + ", " + order
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultReviewLimit
+	}
+
+	items := make([]models.CompanyReview, 0, limit)
+	if err := query.Order(order).Limit(limit).Offset(filter.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return &models.ReviewList{Items: items, Total: total}, nil
+}
+
+// Reply сохраняет ответ компании на опубликованный отзыв о ней.
+func (r *reviewRepository) Reply(ctx context.Context, companyId uint, reviewId uint, reply string) (*models.CompanyReview, error) {
+	var review models.CompanyReview
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("company_id = ? AND status = ?", companyId, models.ReviewPublished).
+			First(&review, reviewId).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrReviewNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&review).Updates(map[string]any{"reply": reply, "replied_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// Moderate публикует или отклоняет отзыв и, если от этого меняется
+// набор опубликованных отзывов, пересчитывает рейтинг компании.
+func (r *reviewRepository) Moderate(
+	ctx context.Context,
+	reviewId uint,
+	status models.ReviewStatus,
+	comment string,
+) (*models.CompanyReview, error) {
+	var review models.CompanyReview
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewId).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrReviewNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		affectsRating := review.Status == models.ReviewPublished || status == models.ReviewPublished
+
+		err = tx.Model(&review).Updates(map[string]any{
+			"status":             status,
+			"moderation_comment": comment,
+			"moderated_at":       time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if affectsRating {
+			return recomputeCompanyRating(tx, review.CompanyID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// Delete удаляет отзыв его автора.
+func (r *reviewRepository) Delete(ctx context.Context, applicantId uint, reviewId uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review models.CompanyReview
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("applicant_id = ?", applicantId).
+			First(&review, reviewId).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrReviewNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+
+		if review.Status == models.ReviewPublished {
+			return recomputeCompanyRating(tx, review.CompanyID)
+		}
+		return nil
+	})
+}
+
+// recomputeCompanyRating пересчитывает рейтинг и число отзывов компании
+// по опубликованным отзывам. Строка компании блокируется, чтобы
+// параллельные пересчёты не затёрли друг друга. Рейтинг вакансий
+// повторяет рейтинг компании: по нему сортируется поиск.
+func recomputeCompanyRating(tx *gorm.DB, companyId uint) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Company{}, companyId).Error; err != nil {
+		return err
+	}
+
+	var stats struct {
+		Rating float64
+		Count  int
+	}
+	err := tx.Model(&models.CompanyReview{}).
+		Select("COALESCE(AVG(rating), 0) AS rating, COUNT(*) AS count").
+		Where("company_id = ? AND status = ?", companyId, models.ReviewPublished).
+		Scan(&stats).Error
+	if err != nil {
+		return err
+	}
+	rating := math.Round(stats.Rating*100) / 100
+
+	// UpdateColumns не трогает updated_at: сам профиль компании не менялся.
+	err = tx.Model(&models.Company{}).Where("id = ?", companyId).
+		UpdateColumns(map[string]any{"rating": rating, "review_count": stats.Count}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Vacancy{}).Where("company_id = ?", companyId).
+		UpdateColumn("rating", rating).Error
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxSavedSearches — сколько поисков может сохранить один соискатель.
+const maxSavedSearches = 20
+
+type SavedSearchRepository interface {
+	Create(ctx context.Context, search *models.SavedSearch) error
+	Get(ctx context.Context, applicantId uint, id uint) (*models.SavedSearch, error)
+	List(ctx context.Context, applicantId uint) ([]models.SavedSearch, error)
+	Update(ctx context.Context, search *models.SavedSearch) error
+	Delete(ctx context.Context, applicantId uint, id uint) error
+
+	ClaimDue(ctx context.Context, tx *gorm.DB, now time.Time) (*models.SavedSearch, error)
+	MarkRun(ctx context.Context, tx *gorm.DB, id uint, ranAt time.Time, nextRunAt time.Time) error
+	Reschedule(ctx context.Context, id uint, nextRunAt time.Time) error
+}
+
+type savedSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) SavedSearchRepository {
+	return &savedSearchRepository{db: db}
+}
+
+// Create сохраняет поиск, если соискатель не превысил лимит. Строка
+// соискателя блокируется, чтобы параллельные запросы не обошли лимит.
+func (r *savedSearchRepository) Create(ctx context.Context, search *models.SavedSearch) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Applicant{}, search.ApplicantID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.SavedSearch{}).Where("applicant_id = ?", search.ApplicantID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxSavedSearches {
+			return constants.ErrSavedSearchLimit
+		}
+
+		return tx.Create(search).Error
+	})
+}
+
+func (r *savedSearchRepository) Get(ctx context.Context, applicantId uint, id uint) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	err := r.db.WithContext(ctx).Where("id = ? AND applicant_id = ?", id, applicantId).Take(&search).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &search, nil
+}
+
+func (r *savedSearchRepository) List(ctx context.Context, applicantId uint) ([]models.SavedSearch, error) {
+	searches := []models.SavedSearch{}
+	err := r.db.WithContext(ctx).Where("applicant_id = ?", applicantId).Order("id").Find(&searches).Error
+	return searches, err
+}
+
+// Update сохраняет название, фильтр и расписание поиска.
+func (r *savedSearchRepository) Update(ctx context.Context, search *models.SavedSearch) error {
+	return r.db.WithContext(ctx).Model(search).
+		Select("name", "filter", "frequency", "next_run_at").
+		Updates(search).Error
+}
+
+func (r *savedSearchRepository) Delete(ctx context.Context, applicantId uint, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND applicant_id = ?", id, applicantId).Delete(&models.SavedSearch{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+// ClaimDue блокирует до конца транзакции tx поиск, подборку по которому
+// пора отправить. SKIP LOCKED не даёт двум инстансам разослать одну
+// подборку дважды. Если таких поисков нет, возвращает nil.
+func (r *savedSearchRepository) ClaimDue(ctx context.Context, tx *gorm.DB, now time.Time) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("next_run_at <= ?", now).
+		Order("next_run_at, id").
+		Take(&search).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &search, nil
+}
+
+// MarkRun запоминает время подборки: следующая начнётся с ranAt.
+func (r *savedSearchRepository) MarkRun(ctx context.Context, tx *gorm.DB, id uint, ranAt time.Time, nextRunAt time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&models.SavedSearch{}).Where("id = ?", id).Updates(map[string]any{
+		"last_run_at": ranAt,
+		"next_run_at": nextRunAt,
+	}).Error
+}
+
+// Reschedule откладывает подборку, не сдвигая начало периода:
+// вакансии за пропущенный период попадут в следующую подборку.
+func (r *savedSearchRepository) Reschedule(ctx context.Context, id uint, nextRunAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.SavedSearch{}).Where("id = ?", id).
+		Update("next_run_at", nextRunAt).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultSavedVacancyLimit = 20
+
+type SavedVacancyRepository interface {
+	Save(ctx context.Context, applicantId uint, vacancyId uint) error
+	Delete(ctx context.Context, applicantId uint, vacancyId uint) error
+	List(ctx context.Context, applicantId uint, filter models.SavedVacancyFilter) (*models.SavedVacancyList, error)
+}
+
+type savedVacancyRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedVacancyRepository(db *gorm.DB) SavedVacancyRepository {
+	return &savedVacancyRepository{db: db}
+}
+
+// Save добавляет закладку; повторное добавление ничего не меняет.
+func (r *savedVacancyRepository) Save(ctx context.Context, applicantId uint, vacancyId uint) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.SavedVacancy{ApplicantID: applicantId, VacancyID: vacancyId}).Error
+}
+
+func (r *savedVacancyRepository) Delete(ctx context.Context, applicantId uint, vacancyId uint) error {
+	return r.db.WithContext(ctx).
+		Where("applicant_id = ? AND vacancy_id = ?", applicantId, vacancyId).
+		Delete(&models.SavedVacancy{}).Error
+}
+
+// List возвращает закладки вместе с вакансиями, последние — первыми.
+func (r *savedVacancyRepository) List(
+	ctx context.Context,
+	applicantId uint,
+	filter models.SavedVacancyFilter,
+) (*models.SavedVacancyList, error) {
+	query := r.db.WithContext(ctx).Model(&models.SavedVacancy{}).Where("applicant_id = ?", applicantId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultSavedVacancyLimit
+	}
+
+	items := make([]models.SavedVacancy, 0, limit)
+	err := query.Preload("Vacancy").
+		Order("created_at DESC, vacancy_id DESC").
+		Limit(limit).Offset(filter.Offset).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SavedVacancyList{Items: items, Total: total}, nil
+}
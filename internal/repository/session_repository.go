@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, session models.Session) (models.Session, error)
+	FindByID(ctx context.Context, tx *gorm.DB, id uuid.UUID) (models.Session, error)
+	ListActive(ctx context.Context, tx *gorm.DB, userID uint, role string) ([]models.Session, error)
+	Touch(ctx context.Context, tx *gorm.DB, id uuid.UUID, userAgent, ip string, expiresAt time.Time) error
+	Revoke(ctx context.Context, tx *gorm.DB, id uuid.UUID, reason string) error
+	RevokeByUserID(ctx context.Context, tx *gorm.DB, userID uint, role string, reason string) error
+	DeleteExpired(ctx context.Context, tx *gorm.DB) (int64, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, tx *gorm.DB, session models.Session) (models.Session, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&session).Error; err != nil {
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, tx *gorm.DB, id uuid.UUID) (models.Session, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var session models.Session
+	if err := tx.WithContext(ctx).Where("id = ?", id).Take(&session).Error; err != nil {
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+// ListActive возвращает неотозванные и неистёкшие сессии, последние использованные — первыми.
+func (r *sessionRepository) ListActive(ctx context.Context, tx *gorm.DB, userID uint, role string) ([]models.Session, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var sessions []models.Session
+	err := tx.WithContext(ctx).
+		Where("user_id = ? AND role = ? AND revoked_at IS NULL AND expires_at > ?", userID, role, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Touch обновляет сведения об устройстве и срок сессии после ротации токена.
+func (r *sessionRepository) Touch(
+	ctx context.Context,
+	tx *gorm.DB,
+	id uuid.UUID,
+	userAgent, ip string,
+	expiresAt time.Time,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&models.Session{}).Where("id = ?", id).Updates(map[string]any{
+		"user_agent":   userAgent,
+		"ip":           ip,
+		"last_used_at": time.Now(),
+		"expires_at":   expiresAt,
+	}).Error
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, tx *gorm.DB, id uuid.UUID, reason string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"revoked_at": time.Now(), "revoke_reason": reason}).Error
+}
+
+func (r *sessionRepository) RevokeByUserID(ctx context.Context, tx *gorm.DB, userID uint, role string, reason string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&models.Session{}).
+		Where("user_id = ? AND role = ? AND revoked_at IS NULL", userID, role).
+		Updates(map[string]any{"revoked_at": time.Now(), "revoke_reason": reason}).Error
+}
+
+// DeleteExpired удаляет истёкшие сессии, в том числе отозванные.
+func (r *sessionRepository) DeleteExpired(ctx context.Context, tx *gorm.DB) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.Session{})
+	return result.RowsAffected, result.Error
+}
@@ -1,17 +1,42 @@
 package repository
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const defaultVacancyLimit = 20
+
+// vacancyDocument — tsvector вакансии для полнотекстового поиска:
+// совпадение в названии весит больше, чем в описании, требованиях и обязанностях.
+const vacancyDocument = `(setweight(to_tsvector('russian', coalesce(vacancies.title, '')), 'A') ||
+	setweight(to_tsvector('russian', coalesce(vacancies.description, '')), 'B') ||
+	setweight(to_tsvector('russian', array_to_string(vacancies.requirements, ' ') || ' ' || array_to_string(vacancies.responsibilities, ' ')), 'C'))`
+
+const vacancyTSQuery = `websearch_to_tsquery('russian', ?)`
+
 type VacancyRepository interface {
-	Search(models.VacancyFilter) ([]models.Vacancy, error)
+	Search(models.VacancyFilter) (*models.VacancySearchResult, error)
 	Create(*models.Vacancy) error
-	GetByCompanyId(uint) ([]models.Vacancy, error)
+	GetByID(id uint) (*models.Vacancy, error)
+	GetByCompanyId(id uint, status models.VacancyStatus) ([]models.Vacancy, error)
 	IsVacancyExists(id uint) (bool, error)
+	Update(id uint, apply func(*models.Vacancy) error) (*models.Vacancy, error)
+	ChangeStatus(id uint, status models.VacancyStatus) (*models.Vacancy, error)
+	Takedown(id uint, reason string) (*models.Vacancy, error)
+	Delete(id uint) error
+	CloseExpired(ctx context.Context, now time.Time) (int64, error)
+	ImportLegacySalary() (int64, error)
 }
 
 type vacancyRepository struct {
@@ -22,26 +47,259 @@ func NewVacancyRepository(db *gorm.DB) VacancyRepository {
 	return &vacancyRepository{db: db}
 }
 
-func (r *vacancyRepository) Search(filter models.VacancyFilter) ([]models.Vacancy, error) {
-	var vacancies []models.Vacancy
-	query := r.db.Model(&models.Vacancy{})
+// vacancyCursor — позиция в выдаче: значение поля сортировки
+// (в текстовом представлении Postgres) и ID последней вакансии.
+// Курсор привязан к сортировке, направлению и поисковому запросу
+// выдачи: значение другой сортировки не приводится к её типу.
+type vacancyCursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Query string `json:"q,omitempty"`
+}
+
+type vacancyRow struct {
+	models.Vacancy
+	SortValue string `gorm:"column:sort_value"`
+}
+
+func (r *vacancyRepository) Search(filter models.VacancyFilter) (*models.VacancySearchResult, error) {
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	sortExpr, sortType, sortArgs := vacancySortExpression(filter)
+
+	query := r.filtered(filter).
+		Select("vacancies.*, ("+sortExpr+")::text AS sort_value", sortArgs...)
+
+	direction, compare := "DESC", "<"
+	if filter.Order == "asc" {
+		direction, compare = "ASC", ">"
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeVacancyCursor(filter.Cursor, filter)
+		if err != nil {
+			return nil, err
+		}
+		args := append(append([]any{}, sortArgs...), cursor.Value, cursor.ID)
+		query = query.Where("("+sortExpr+", vacancies.id) "+compare+" (CAST(? AS "+sortType+"), ?)", args...)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultVacancyLimit
+	}
+
+	var rows []vacancyRow
+	if err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  sortExpr + " " + direction + ", vacancies.id " + direction,
+			Vars: sortArgs,
+		}}).
+		Limit(limit + 1).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := &models.VacancySearchResult{
+		Items: make([]models.Vacancy, 0, len(rows)),
+		Total: total,
+	}
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		next := vacancyCursorScope(filter)
+		next.Value, next.ID = last.SortValue, last.ID
+		result.NextCursor = encodeVacancyCursor(next)
+	}
+
+	for _, row := range rows {
+		result.Items = append(result.Items, row.Vacancy)
+	}
+
+	return result, nil
+}
+
+// publishedVacancies оставляет опубликованные вакансии, срок которых
+// не истёк. Истёкшие закрывает воркер, но до его прохода они уже скрыты.
+func publishedVacancies(db *gorm.DB) *gorm.DB {
+	return db.Where("vacancies.status = ? AND (vacancies.expires_at IS NULL OR vacancies.expires_at > ?)",
+		models.VacancyPublished, time.Now())
+}
+
+// filtered применяет к запросу все условия фильтра, кроме курсора.
+func (r *vacancyRepository) filtered(filter models.VacancyFilter) *gorm.DB {
+	query := r.db.Model(&models.Vacancy{}).Scopes(publishedVacancies)
 
-	title := strings.ReplaceAll(*filter.Title, "%", "\\%")
-	title = strings.ReplaceAll(title, "_", "\\_")
 	if filter.Title != nil {
-		query = query.Where("title ILIKE ? ESCAPE '\\'", "%"+title+"%")
+		query = query.Where("vacancies.title ILIKE ? ESCAPE '\\'", "%"+escapeLike(*filter.Title)+"%")
+	}
+	if filter.Query != nil && strings.TrimSpace(*filter.Query) != "" {
+		query = query.Where(vacancyDocument+" @@ "+vacancyTSQuery, *filter.Query)
+	}
+	if filter.SalaryMin != nil {
+		query = query.Where("COALESCE(vacancies.salary_max, vacancies.salary_min) >= ?", *filter.SalaryMin)
+	}
+	if filter.SalaryMax != nil {
+		query = query.Where("COALESCE(vacancies.salary_min, vacancies.salary_max) <= ?", *filter.SalaryMax)
+	}
+	if filter.SalaryCurrency != nil {
+		query = query.Where("vacancies.salary_currency = ?", *filter.SalaryCurrency)
+	}
+	if filter.City != nil && strings.TrimSpace(*filter.City) != "" {
+		query = query.Where("lower(vacancies.city) = lower(?)", strings.TrimSpace(*filter.City))
+	}
+	if len(filter.WorkModes) > 0 {
+		query = query.Where("vacancies.work_mode IN ?", filter.WorkModes)
+	}
+	if len(filter.EmploymentTypes) > 0 {
+		query = query.Where("vacancies.employment_type IN ?", filter.EmploymentTypes)
+	}
+	if len(filter.ExperienceLevels) > 0 {
+		query = query.Where("vacancies.experience_level IN ?", filter.ExperienceLevels)
+	}
+	if filter.CompanyID != nil {
+		query = query.Where("vacancies.company_id = ?", *filter.CompanyID)
+	}
+	if filter.PostedSince != nil {
+		query = query.Where("vacancies.created_at >= ?", *filter.PostedSince)
+	}
+	if filter.PublishedSince != nil {
+		query = query.Where("vacancies.published_at >= ?", *filter.PublishedSince)
+	}
+	if filter.PublishedBefore != nil {
+		query = query.Where("vacancies.published_at < ?", *filter.PublishedBefore)
+	}
+	for _, skill := range filter.Skills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" {
+			continue
+		}
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM unnest(vacancies.requirements) AS req WHERE req ILIKE ? ESCAPE '\\')",
+			"%"+escapeLike(skill)+"%",
+		)
+	}
+
+	return query
+}
+
+// vacancySortExpression возвращает SQL-выражение для сортировки, его тип
+// (для приведения значения из курсора) и аргументы выражения.
+// Сортировка по релевантности без поискового запроса не имеет смысла,
+// поэтому в этом случае используется дата публикации.
+func vacancySortExpression(filter models.VacancyFilter) (string, string, []any) {
+	hasQuery := filter.Query != nil && strings.TrimSpace(*filter.Query) != ""
+
+	sort := filter.Sort
+	if sort == "" && hasQuery {
+		sort = models.VacancySortRelevance
+	}
+
+	switch sort {
+	case models.VacancySortRelevance:
+		if hasQuery {
+			return "ts_rank(" + vacancyDocument + ", " + vacancyTSQuery + ")::double precision", "double precision", []any{*filter.Query}
+		}
+	case models.VacancySortSalary:
+		// Вакансии без зарплаты уходят в конец при сортировке по убыванию.
+		return "COALESCE(vacancies.salary_max, vacancies.salary_min, 0)", "integer", nil
+	case models.VacancySortRating:
+		return "vacancies.rating", "double precision", nil
+	}
+
+	return "vacancies.created_at", "timestamp with time zone", nil
+}
+
+// vacancyCursorScope возвращает курсор без позиции: сортировку и направление
+// выдачи с учётом значений по умолчанию и хеш поискового запроса.
+func vacancyCursorScope(filter models.VacancyFilter) vacancyCursor {
+	scope := vacancyCursor{Sort: filter.Sort, Order: filter.Order}
+
+	query := ""
+	if filter.Query != nil {
+		query = strings.TrimSpace(*filter.Query)
 	}
-	if err := query.Find(&vacancies).Error; err != nil {
+	if query != "" {
+		hash := fnv.New64a()
+		hash.Write([]byte(query))
+		scope.Query = strconv.FormatUint(hash.Sum64(), 36)
+	}
+
+	if scope.Sort == "" {
+		scope.Sort = models.VacancySortDate
+		if query != "" {
+			scope.Sort = models.VacancySortRelevance
+		}
+	}
+	if scope.Order != "asc" {
+		scope.Order = "desc"
+	}
+
+	return scope
+}
+
+func encodeVacancyCursor(cursor vacancyCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeVacancyCursor разбирает курсор и проверяет, что он выдан
+// для той же сортировки, направления и запроса, что и filter.
+func decodeVacancyCursor(value string, filter models.VacancyFilter) (vacancyCursor, error) {
+	var cursor vacancyCursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, constants.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == 0 {
+		return cursor, constants.ErrInvalidCursor
+	}
+
+	scope := vacancyCursorScope(filter)
+	if cursor.Sort != scope.Sort || cursor.Order != scope.Order || cursor.Query != scope.Query {
+		return cursor, constants.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func escapeLike(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "%", "\\%")
+	return strings.ReplaceAll(value, "_", "\\_")
+}
+
+func (r *vacancyRepository) GetByID(id uint) (*models.Vacancy, error) {
+	var vacancy models.Vacancy
+	if err := r.db.First(&vacancy, id).Error; err != nil {
 		return nil, err
 	}
 
-	return vacancies, nil
+	return &vacancy, nil
 }
 
-func (r *vacancyRepository) GetByCompanyId(id uint) ([]models.Vacancy, error) {
+// GetByCompanyId возвращает вакансии компании в статусе status
+// (пустой — в любом). Для published истёкшие не возвращаются.
+func (r *vacancyRepository) GetByCompanyId(id uint, status models.VacancyStatus) ([]models.Vacancy, error) {
 	var vacancies []models.Vacancy
 
-	if err := r.db.Where("company_id = ?", id).Find(&vacancies).Error; err != nil {
+	query := r.db.Where("company_id = ?", id)
+	switch status {
+	case "":
+	case models.VacancyPublished:
+		query = query.Scopes(publishedVacancies)
+	default:
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Find(&vacancies).Error; err != nil {
 		return nil, err
 	}
 
@@ -60,3 +318,140 @@ func (r *vacancyRepository) IsVacancyExists(id uint) (bool, error) {
 
 	return count > 0, nil
 }
+
+// Update применяет apply к заблокированной вакансии и сохраняет её.
+// Закрытые и архивные вакансии не изменяются.
+func (r *vacancyRepository) Update(id uint, apply func(*models.Vacancy) error) (*models.Vacancy, error) {
+	var vacancy models.Vacancy
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&vacancy, id).Error; err != nil {
+			return err
+		}
+		if !vacancy.Status.IsEditable() {
+			return constants.ErrVacancyNotEditable
+		}
+		if err := apply(&vacancy); err != nil {
+			return err
+		}
+
+		return tx.Save(&vacancy).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vacancy, nil
+}
+
+// ChangeStatus переводит вакансию в новый статус, если переход разрешён.
+// Опубликовать вакансию с прошедшим сроком нельзя: сначала его нужно продлить.
+func (r *vacancyRepository) ChangeStatus(id uint, status models.VacancyStatus) (*models.Vacancy, error) {
+	var vacancy models.Vacancy
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&vacancy, id).Error; err != nil {
+			return err
+		}
+		if !vacancy.Status.CanTransitionTo(status) {
+			return constants.ErrInvalidVacancyTransition
+		}
+
+		now := time.Now()
+		updates := map[string]any{"status": status}
+		switch status {
+		case models.VacancyPublished:
+			if vacancy.ExpiresAt != nil && !vacancy.ExpiresAt.After(now) {
+				return constants.ErrVacancyExpired
+			}
+			updates["published_at"] = now
+			updates["closed_at"] = nil
+		case models.VacancyClosed:
+			updates["closed_at"] = now
+		}
+
+		return tx.Model(&vacancy).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vacancy, nil
+}
+
+// Takedown снимает вакансию по решению администратора из любого статуса.
+func (r *vacancyRepository) Takedown(id uint, reason string) (*models.Vacancy, error) {
+	var vacancy models.Vacancy
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&vacancy, id).Error; err != nil {
+			return err
+		}
+		if vacancy.Status == models.VacancyTakenDown {
+			return constants.ErrInvalidVacancyTransition
+		}
+
+		now := time.Now()
+		updates := map[string]any{
+			"status":          models.VacancyTakenDown,
+			"takedown_reason": reason,
+			"taken_down_at":   now,
+		}
+		if vacancy.ClosedAt == nil {
+			updates["closed_at"] = now
+		}
+
+		return tx.Model(&vacancy).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vacancy, nil
+}
+
+// Delete удаляет черновик. Остальные вакансии нужно архивировать,
+// чтобы не терять отклики на них.
+func (r *vacancyRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var vacancy models.Vacancy
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&vacancy, id).Error; err != nil {
+			return err
+		}
+		if vacancy.Status != models.VacancyDraft {
+			return constants.ErrVacancyNotDraft
+		}
+
+		return tx.Delete(&vacancy).Error
+	})
+}
+
+// CloseExpired закрывает опубликованные и приостановленные вакансии,
+// срок которых наступил к now.
+func (r *vacancyRepository) CloseExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Vacancy{}).
+		Where("status IN ? AND expires_at <= ?", []models.VacancyStatus{models.VacancyPublished, models.VacancyPaused}, now).
+		Updates(map[string]any{"status": models.VacancyClosed, "closed_at": now})
+
+	return result.RowsAffected, result.Error
+}
+
+// ImportLegacySalary переносит единственную зарплату из старой колонки
+// salary в обе границы вилки и удаляет колонку: она NOT NULL и без
+// значения по умолчанию, поэтому мешала бы создавать новые вакансии.
+func (r *vacancyRepository) ImportLegacySalary() (int64, error) {
+	var imported int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`UPDATE vacancies SET salary_min = salary, salary_max = salary
+			WHERE salary > 0 AND salary_min IS NULL AND salary_max IS NULL`)
+		if result.Error != nil {
+			return result.Error
+		}
+		imported = result.RowsAffected
+
+		return tx.Migrator().DropColumn(&models.Vacancy{}, "salary")
+	})
+
+	return imported, err
+}
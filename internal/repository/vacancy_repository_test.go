@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+)
+
+func TestVacancyCursorRoundTrip(t *testing.T) {
+	query := "golang"
+	tests := []struct {
+		name   string
+		filter models.VacancyFilter
+		value  string
+		id     uint
+	}{
+		{name: "timestamp", value: "2026-03-01 10:00:00.123456+00", id: 42},
+		{name: "number", filter: models.VacancyFilter{Query: &query}, value: "0.0607927", id: 1},
+		{name: "empty value", filter: models.VacancyFilter{Sort: models.VacancySortSalary, Order: "asc"}, id: 7},
+		{name: "unicode", value: "Москва / \"офис\"", id: 1 << 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := vacancyCursorScope(tt.filter)
+			cursor.Value, cursor.ID = tt.value, tt.id
+
+			got, err := decodeVacancyCursor(encodeVacancyCursor(cursor), tt.filter)
+			if err != nil {
+				t.Fatalf("decodeVacancyCursor() error = %v", err)
+			}
+			if got != cursor {
+				t.Errorf("decodeVacancyCursor() = %+v, want %+v", got, cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeVacancyCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "not base64", value: "!!!"},
+		{name: "padded base64", value: base64.URLEncoding.EncodeToString([]byte(`{"v":"a","id":1,"s":"date","o":"desc"}`))},
+		{name: "not json", value: encode("cursor")},
+		{name: "no id", value: encode(`{"v":"a","s":"date","o":"desc"}`)},
+		{name: "zero id", value: encode(`{"v":"a","id":0,"s":"date","o":"desc"}`)},
+		{name: "negative id", value: encode(`{"v":"a","id":-1,"s":"date","o":"desc"}`)},
+		{name: "wrong value type", value: encode(`{"v":1,"id":1,"s":"date","o":"desc"}`)},
+		{name: "no scope", value: encode(`{"v":"a","id":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeVacancyCursor(tt.value, models.VacancyFilter{})
+			if !errors.Is(err, constants.ErrInvalidCursor) {
+				t.Errorf("decodeVacancyCursor(%q) error = %v, want %v", tt.value, err, constants.ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestDecodeVacancyCursorScopeMismatch(t *testing.T) {
+	golang, padded, python := "golang", " golang ", "python"
+	issued := models.VacancyFilter{Sort: models.VacancySortSalary, Order: "asc", Query: &golang}
+
+	cursor := vacancyCursorScope(issued)
+	cursor.Value, cursor.ID = "200000", 5
+	value := encodeVacancyCursor(cursor)
+
+	tests := []struct {
+		name    string
+		filter  models.VacancyFilter
+		wantErr error
+	}{
+		{name: "same scope", filter: issued},
+		{
+			name:   "query whitespace",
+			filter: models.VacancyFilter{Sort: models.VacancySortSalary, Order: "asc", Query: &padded},
+		},
+		{
+			name:    "other sort",
+			filter:  models.VacancyFilter{Sort: models.VacancySortDate, Order: "asc", Query: &golang},
+			wantErr: constants.ErrInvalidCursor,
+		},
+		{
+			name:    "other order",
+			filter:  models.VacancyFilter{Sort: models.VacancySortSalary, Query: &golang},
+			wantErr: constants.ErrInvalidCursor,
+		},
+		{
+			name:    "other query",
+			filter:  models.VacancyFilter{Sort: models.VacancySortSalary, Order: "asc", Query: &python},
+			wantErr: constants.ErrInvalidCursor,
+		},
+		{
+			name:    "no query",
+			filter:  models.VacancyFilter{Sort: models.VacancySortSalary, Order: "asc"},
+			wantErr: constants.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeVacancyCursor(value, tt.filter); !errors.Is(err, tt.wantErr) {
+				t.Errorf("decodeVacancyCursor() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVacancyCursorScopeDefaults(t *testing.T) {
+	query := "golang"
+	tests := []struct {
+		name      string
+		filter    models.VacancyFilter
+		wantSort  string
+		wantOrder string
+	}{
+		{name: "no sort", wantSort: models.VacancySortDate, wantOrder: "desc"},
+		{name: "query without sort", filter: models.VacancyFilter{Query: &query}, wantSort: models.VacancySortRelevance, wantOrder: "desc"},
+		{name: "explicit", filter: models.VacancyFilter{Sort: models.VacancySortRating, Order: "asc"}, wantSort: models.VacancySortRating, wantOrder: "asc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := vacancyCursorScope(tt.filter)
+			if got.Sort != tt.wantSort || got.Order != tt.wantOrder {
+				t.Errorf("vacancyCursorScope() = %s %s, want %s %s", got.Sort, got.Order, tt.wantSort, tt.wantOrder)
+			}
+		})
+	}
+}
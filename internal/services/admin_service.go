@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/dto"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/pkg/helpers"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AdminService — консоль модерации платформы. Блокировки, снятие
+// контента и вход под пользователем записываются в журнал.
+type AdminService interface {
+	EnsureAdmin(ctx context.Context, email string, password string) error
+	Accounts(ctx context.Context, role string, filter models.AccountFilter) (*models.AccountList, error)
+	Block(ctx context.Context, adminId uint, role string, userId uint, reason string) error
+	Unblock(ctx context.Context, adminId uint, role string, userId uint) error
+	TakedownVacancy(ctx context.Context, adminId uint, vacancyId uint, reason string) (*models.Vacancy, error)
+	TakedownReview(ctx context.Context, adminId uint, reviewId uint, reason string) (*models.CompanyReview, error)
+	Impersonate(ctx context.Context, adminId uint, req models.ImpersonateRequest) (dto.TokenResponse, error)
+	Actions(ctx context.Context, filter models.AdminActionFilter) (*models.AdminActionList, error)
+	Stats(ctx context.Context) (*models.PlatformStats, error)
+}
+
+type adminService struct {
+	repo          repository.AdminRepository
+	vacancyRepo   repository.VacancyRepository
+	reviewService ReviewService
+	jwtService    JWTService
+}
+
+func NewAdminService(
+	repo repository.AdminRepository,
+	vacancyRepo repository.VacancyRepository,
+	reviewService ReviewService,
+	jwtService JWTService,
+) AdminService {
+	return &adminService{
+		repo:          repo,
+		vacancyRepo:   vacancyRepo,
+		reviewService: reviewService,
+		jwtService:    jwtService,
+	}
+}
+
+// EnsureAdmin создаёт администратора, если его ещё нет. Пароль
+// существующего администратора не меняется.
+func (s *adminService) EnsureAdmin(ctx context.Context, email string, password string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	_, err := s.repo.GetByEmail(ctx, email)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, &models.Admin{Email: email, Password: hashedPassword})
+}
+
+func (s *adminService) Accounts(ctx context.Context, role string, filter models.AccountFilter) (*models.AccountList, error) {
+	return s.repo.Accounts(ctx, role, filter)
+}
+
+// Block блокирует соискателя или компанию. Сессии не завершаются:
+// Authenticate и обновление токенов отклоняют заблокированных сразу,
+// а после разблокировки пользователю не нужно входить заново.
+func (s *adminService) Block(ctx context.Context, adminId uint, role string, userId uint, reason string) error {
+	if err := s.repo.SetBlocked(ctx, role, userId, true, reason); err != nil {
+		return err
+	}
+
+	return s.record(ctx, adminId, models.AdminActionBlock, role, userId, reason)
+}
+
+func (s *adminService) Unblock(ctx context.Context, adminId uint, role string, userId uint) error {
+	if err := s.repo.SetBlocked(ctx, role, userId, false, ""); err != nil {
+		return err
+	}
+
+	return s.record(ctx, adminId, models.AdminActionUnblock, role, userId, "")
+}
+
+// TakedownVacancy снимает вакансию с публикации без права восстановления.
+func (s *adminService) TakedownVacancy(ctx context.Context, adminId uint, vacancyId uint, reason string) (*models.Vacancy, error) {
+	vacancy, err := s.vacancyRepo.Takedown(vacancyId, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, adminId, models.AdminActionTakedownVacancy, models.AdminTargetVacancy, vacancyId, reason); err != nil {
+		return nil, err
+	}
+
+	return vacancy, nil
+}
+
+// TakedownReview отклоняет отзыв с причиной: он пропадает из публичного
+// списка и из рейтинга компании.
+func (s *adminService) TakedownReview(ctx context.Context, adminId uint, reviewId uint, reason string) (*models.CompanyReview, error) {
+	review, err := s.reviewService.Moderate(ctx, reviewId, models.ModerateReviewRequest{
+		Status:  models.ReviewRejected,
+		Comment: reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, adminId, models.AdminActionTakedownReview, models.AdminTargetReview, reviewId, reason); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// Impersonate выдаёт администратору короткий access-токен пользователя
+// для разбора обращения в поддержку. Refresh-токена нет: по истечении
+// токена вход нужно запросить снова, и каждый вход попадает в журнал.
+func (s *adminService) Impersonate(ctx context.Context, adminId uint, req models.ImpersonateRequest) (dto.TokenResponse, error) {
+	exists, err := s.repo.Exists(ctx, req.UserID, req.Role)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	if !exists {
+		return dto.TokenResponse{}, gorm.ErrRecordNotFound
+	}
+
+	blocked, err := s.repo.IsBlocked(ctx, req.UserID, req.Role)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	if blocked {
+		return dto.TokenResponse{}, constants.ErrAccountBlocked
+	}
+
+	if err := s.record(ctx, adminId, models.AdminActionImpersonate, req.Role, req.UserID, req.Reason); err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return dto.TokenResponse{
+		AccessToken: s.jwtService.GenerateImpersonationToken(req.UserID, req.Role, adminId),
+		Role:        req.Role,
+	}, nil
+}
+
+func (s *adminService) Actions(ctx context.Context, filter models.AdminActionFilter) (*models.AdminActionList, error) {
+	return s.repo.Actions(ctx, filter)
+}
+
+func (s *adminService) Stats(ctx context.Context) (*models.PlatformStats, error) {
+	return s.repo.Stats(ctx)
+}
+
+func (s *adminService) record(ctx context.Context, adminId uint, action string, targetRole string, targetId uint, reason string) error {
+	return s.repo.Record(ctx, &models.AdminAction{
+		AdminID:    adminId,
+		Action:     action,
+		TargetRole: targetRole,
+		TargetID:   targetId,
+		Reason:     reason,
+	})
+}
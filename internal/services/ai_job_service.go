@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+)
+
+type AIJobService interface {
+	GetByID(userId uint, role string, id uint) (*models.AIJob, error)
+}
+
+type aiJobService struct {
+	repo repository.AIJobRepository
+}
+
+func NewAIJobService(repo repository.AIJobRepository) AIJobService {
+	return &aiJobService{repo: repo}
+}
+
+func (s *aiJobService) GetByID(userId uint, role string, id uint) (*models.AIJob, error) {
+	job, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.OwnerID != userId || job.OwnerRole != role {
+		return nil, constants.ErrForbidden
+	}
+
+	return job, nil
+}
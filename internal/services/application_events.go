@@ -0,0 +1,26 @@
+package services
+
+import (
+	"github.com/AliUmarov/team-find-me-job/internal/events"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+)
+
+// События об откликах получают обе стороны: другая сторона узнаёт
+// об изменении, а автор видит его в остальных открытых вкладках.
+// Сотрудники компании подписаны на её поток и получают их вместе с ней.
+
+func applicationRecipients(applicantId, companyId uint) []events.Recipient {
+	return []events.Recipient{
+		{UserID: applicantId, Role: models.RoleApplicant},
+		{UserID: companyId, Role: models.RoleCompany},
+	}
+}
+
+func applicationStatusChangedEvent(app *models.Application, change *models.ApplicationStatusChange) events.Event {
+	payload := events.NewApplicationPayload(app)
+	payload.Status = change.ToStatus
+	payload.FromStatus = change.FromStatus
+	payload.ChangedByRole = change.ChangedByRole
+
+	return events.Event{Type: events.TypeApplicationStatusChanged, Data: payload}
+}
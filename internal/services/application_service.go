@@ -1,35 +1,66 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"log/slog"
+	"time"
 
+	"github.com/AliUmarov/team-find-me-job/internal/ai"
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/events"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
 )
 
 type ApplicationService interface {
-	Create(models.CreateApplication) (*models.Application, error)
+	Create(applicantId uint, dto models.CreateApplication) (*models.Application, error)
+	Withdraw(applicantId uint, appId uint, req models.WithdrawApplicationRequest) (*models.Application, error)
+	History(userId uint, role string, appId uint) ([]models.ApplicationStatusChange, error)
+	ProcessMatch(ctx context.Context, appId uint) (*models.Application, error)
 }
 
 type applicationService struct {
 	applicationRepo repository.ApplicationRepository
 	vacancyRepo     repository.VacancyRepository
 	resumeRepo      repository.ResumeRepository
+	jobRepo         repository.AIJobRepository
+	access          companyAccess
+	logger          *slog.Logger
+	llm             ai.LLMProvider
+	notifications   NotificationService
+	interviews      InterviewService
+	broker          events.Broker
 }
 
 func NewApplicationService(
 	applicationRepo repository.ApplicationRepository,
 	vacancyRepo repository.VacancyRepository,
 	resumeRepo repository.ResumeRepository,
+	jobRepo repository.AIJobRepository,
+	memberRepo repository.MemberRepository,
+	logger *slog.Logger,
+	llm ai.LLMProvider,
+	notifications NotificationService,
+	interviews InterviewService,
+	broker events.Broker,
 ) ApplicationService {
 	return &applicationService{
 		applicationRepo: applicationRepo,
 		vacancyRepo:     vacancyRepo,
 		resumeRepo:      resumeRepo,
+		jobRepo:         jobRepo,
+		access:          companyAccess{members: memberRepo},
+		logger:          logger,
+		llm:             llm,
+		notifications:   notifications,
+		interviews:      interviews,
+		broker:          broker,
 	}
 }
 
-func (s *applicationService) Create(dto models.CreateApplication) (*models.Application, error) {
+func (s *applicationService) Create(applicantId uint, dto models.CreateApplication) (*models.Application, error) {
 	isVacancyExists, err := s.vacancyRepo.IsVacancyExists(dto.VacancyID)
 	if err != nil {
 		return nil, err
@@ -46,15 +77,148 @@ func (s *applicationService) Create(dto models.CreateApplication) (*models.Appli
 		return nil, errors.New("resume is not exists")
 	}
 
+	resume, err := s.resumeRepo.GetByID(dto.ResumeID)
+	if err != nil {
+		return nil, err
+	}
+	if resume.ApplicantID != applicantId {
+		return nil, constants.ErrForbidden
+	}
+
+	vacancy, err := s.vacancyRepo.GetByID(dto.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if !vacancy.IsOpen(time.Now()) {
+		return nil, constants.ErrVacancyNotPublished
+	}
+
 	application := &models.Application{
 		Status:    models.StatusPending,
 		VacancyID: dto.VacancyID,
 		ResumeID:  dto.ResumeID,
 	}
 
-	if err := s.applicationRepo.Create(application); err != nil {
+	notify := func(tx *gorm.DB, app *models.Application, _ *models.ApplicationStatusChange) error {
+		if err := s.enqueueMatch(tx, app, applicantId); err != nil {
+			return err
+		}
+		return s.notifications.ApplicationReceived(context.Background(), tx, app)
+	}
+	if err := s.applicationRepo.Create(application, applicantId, notify); err != nil {
 		return nil, err
 	}
 
+	s.broker.Publish(context.Background(),
+		events.Event{Type: events.TypeApplicationCreated, Data: events.NewApplicationPayload(application)},
+		applicationRecipients(applicantId, vacancy.CompanyID)...,
+	)
+
 	return application, nil
 }
+
+// enqueueMatch ставит оценку соответствия резюме вакансии в очередь
+// ИИ-задач в транзакции отклика: отклик не ждёт модель, а оценку
+// позже запишет воркер через ProcessMatch.
+func (s *applicationService) enqueueMatch(tx *gorm.DB, app *models.Application, applicantId uint) error {
+	if s.llm == nil {
+		return nil
+	}
+
+	job := models.AIJob{
+		Type:          models.AIJobTypeMatchApplication,
+		Status:        models.AIJobQueued,
+		ResumeID:      app.ResumeID,
+		ApplicationID: &app.ID,
+		OwnerID:       applicantId,
+		OwnerRole:     models.RoleApplicant,
+		RunAt:         time.Now(),
+	}
+
+	return s.jobRepo.Enqueue(context.Background(), tx, &job)
+}
+
+// ProcessMatch оценивает соответствие резюме вакансии и сохраняет оценку
+// в отклике. Вызывается воркером ИИ-задач; ошибку модели он повторит
+// или оставит отклик без оценки.
+func (s *applicationService) ProcessMatch(ctx context.Context, appId uint) (*models.Application, error) {
+	application, err := s.applicationRepo.GetByID(appId)
+	if err != nil {
+		return nil, err
+	}
+
+	resume, err := s.resumeRepo.GetByID(application.ResumeID)
+	if err != nil {
+		return nil, err
+	}
+	vacancy, err := s.vacancyRepo.GetByID(application.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := ai.MatchResumeToVacancy(ctx, s.llm, *resume, *vacancy)
+	if err != nil {
+		return nil, err
+	}
+
+	application.MatchScore = &match.Score
+	application.MatchedRequirements = match.Matched
+	application.MissingRequirements = match.Missing
+	application.MatchJustification = match.Justification
+	if err := s.applicationRepo.SaveMatch(ctx, application); err != nil {
+		return nil, err
+	}
+
+	return application, nil
+}
+
+func (s *applicationService) Withdraw(applicantId uint, appId uint, req models.WithdrawApplicationRequest) (*models.Application, error) {
+	current, err := s.checkAccess(applicantId, models.RoleApplicant, appId, accessWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed *models.ApplicationStatusChange
+	notify := func(tx *gorm.DB, app *models.Application, change *models.ApplicationStatusChange) error {
+		changed = change
+		if err := s.notifications.ApplicationStatusChanged(context.Background(), tx, app, change); err != nil {
+			return err
+		}
+		return s.interviews.ApplicationClosed(context.Background(), tx, app, change)
+	}
+	app, err := s.applicationRepo.ChangeStatus(appId, models.StatusWithdrawn, applicantId, models.RoleApplicant, req.Comment, notify)
+	if err != nil {
+		return nil, err
+	}
+
+	s.broker.Publish(context.Background(),
+		applicationStatusChangedEvent(app, changed),
+		applicationRecipients(applicantId, current.Vacancy.CompanyID)...,
+	)
+
+	return app, nil
+}
+
+func (s *applicationService) History(userId uint, role string, appId uint) ([]models.ApplicationStatusChange, error) {
+	if _, err := s.checkAccess(userId, role, appId, accessRead); err != nil {
+		return nil, err
+	}
+
+	return s.applicationRepo.History(appId)
+}
+
+// checkAccess пускает к отклику только его стороны: владельца резюме
+// и компанию-владельца вакансии с её сотрудниками. Возвращает отклик
+// с вакансией и резюме.
+func (s *applicationService) checkAccess(userId uint, role string, appId uint, mode accessMode) (*models.Application, error) {
+	app, err := s.applicationRepo.GetByID(appId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.access.checkApplication(context.Background(), app, userId, role, mode); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
@@ -2,52 +2,99 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/dto"
+	"github.com/AliUmarov/team-find-me-job/internal/mail"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/pkg/helpers"
-	"github.com/AliUmarov/team-find-me-job/internal/pkg/utils"
 	"github.com/AliUmarov/team-find-me-job/internal/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	// maxDeviceNameLength соответствует размеру колонки sessions.device.
+	maxDeviceNameLength = 255
+
+	emailVerificationTTL = 24 * time.Hour
+	passwordResetTTL     = time.Hour
+	// streamTicketTTL — клиенту хватает нескольких секунд, чтобы открыть
+	// поток событий с полученным билетом.
+	streamTicketTTL = 30 * time.Second
+)
+
 type AuthService interface {
 	RegisterApplicant(ctx context.Context, req dto.ApplicantRegisterRequest) (dto.ApplicantResponse, error)
-	Login(ctx context.Context, req dto.ApplicantLoginRequest) (dto.TokenResponse, error)
-	RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.TokenResponse, error)
-	Logout(ctx context.Context, userId string) error
+	Login(ctx context.Context, req dto.ApplicantLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error)
+	RegisterCompany(ctx context.Context, req models.CompanyRegisterRequest) (dto.CompanyResponse, error)
+	LoginCompany(ctx context.Context, req dto.CompanyLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error)
+	LoginCompanyMember(ctx context.Context, req dto.CompanyLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error)
+	AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest, client dto.ClientInfo) (dto.TokenResponse, error)
+	LoginAdmin(ctx context.Context, req dto.CompanyLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error)
+	IsBlocked(ctx context.Context, userId uint, role string) (bool, error)
+	RecordImpersonated(ctx context.Context, adminId uint, role string, userId uint, request string)
+	RefreshToken(ctx context.Context, req dto.RefreshTokenRequest, client dto.ClientInfo) (dto.TokenResponse, error)
+	Logout(ctx context.Context, userId uint, role string) error
+	ListSessions(ctx context.Context, userId uint, role string, currentSessionId string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userId uint, role string, sessionId uuid.UUID) error
 	SendVerificationEmail(ctx context.Context, req dto.SendVerificationEmailRequest) error
 	VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (dto.VerifyEmailResponse, error)
 	SendPasswordReset(ctx context.Context, req dto.SendPasswordResetRequest) error
 	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+	IssueStreamTicket(ctx context.Context, userId uint, role string) (string, error)
+	ConsumeStreamTicket(ctx context.Context, ticket string) (uint, string, error)
 	GetJWTService() *JWTService
 }
 
 type authService struct {
 	applicantRepo          repository.ApplicantRepository
 	companyRepo            repository.CompanyRepository
+	memberRepo             repository.MemberRepository
+	adminRepo              repository.AdminRepository
 	refreshTokenRepository repository.RefreshTokenRepository
+	sessionRepository      repository.SessionRepository
+	oneTimeTokenRepository repository.OneTimeTokenRepository
+	notificationService    NotificationService
 	jwtService             JWTService
+	logger                 *slog.Logger
 	db                     *gorm.DB
 }
 
 func NewAuthService(
 	applicantRepo repository.ApplicantRepository,
 	companyRepo repository.CompanyRepository,
+	memberRepo repository.MemberRepository,
+	adminRepo repository.AdminRepository,
 	logger *slog.Logger,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	oneTimeTokenRepo repository.OneTimeTokenRepository,
+	notificationService NotificationService,
 	jwtService JWTService,
 	db *gorm.DB,
 ) AuthService {
 	return &authService{
 		applicantRepo:          applicantRepo,
 		companyRepo:            companyRepo,
+		memberRepo:             memberRepo,
+		adminRepo:              adminRepo,
 		refreshTokenRepository: refreshTokenRepo,
+		sessionRepository:      sessionRepo,
+		oneTimeTokenRepository: oneTimeTokenRepo,
+		notificationService:    notificationService,
 		jwtService:             jwtService,
+		logger:                 logger,
 		db:                     db,
 	}
 }
@@ -72,8 +119,9 @@ func (s *authService) RegisterApplicant(ctx context.Context, req dto.ApplicantRe
 		Email:      req.Email,
 		Phone:      req.Phone,
 		Password:   hashedPassword,
-		Role:       "APPLICANT",
+		Role:       models.RoleApplicant,
 		IsVerified: false,
+		Locale:     string(mail.ParseLanguage(req.Locale)),
 	}
 
 	createdUser, err := s.applicantRepo.Register(ctx, s.db, user)
@@ -87,10 +135,11 @@ func (s *authService) RegisterApplicant(ctx context.Context, req dto.ApplicantRe
 		Phone:      createdUser.Phone,
 		Role:       createdUser.Role,
 		IsVerified: createdUser.IsVerified,
+		Locale:     createdUser.Locale,
 	}, nil
 }
 
-func (s *authService) Login(ctx context.Context, req dto.ApplicantLoginRequest) (dto.TokenResponse, error) {
+func (s *authService) Login(ctx context.Context, req dto.ApplicantLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error) {
 	user, err := s.applicantRepo.GetApplicantByEmail(ctx, s.db, req.Email)
 	if err != nil {
 		return dto.TokenResponse{}, dto.ErrEmailNotFound
@@ -102,63 +151,376 @@ func (s *authService) Login(ctx context.Context, req dto.ApplicantLoginRequest)
 		return dto.TokenResponse{}, constants.ErrInvalidCredentials
 	}
 
-	accessToken := s.jwtService.GenerateAccessToken(user.ID, user.Role)
+	return s.issueTokens(ctx, user.ID, user.Role, client)
+}
+
+func (s *authService) RegisterCompany(ctx context.Context, req models.CompanyRegisterRequest) (dto.CompanyResponse, error) {
+	isExist, err := s.companyRepo.CheckEmail(ctx, s.db, req.Email)
+	if err != nil {
+		return dto.CompanyResponse{}, err
+	}
+
+	if isExist {
+		return dto.CompanyResponse{}, dto.ErrEmailAlreadyExists
+	}
+
+	hashedPassword, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		return dto.CompanyResponse{}, err
+	}
+
+	company := models.Company{
+		Name:        req.Name,
+		Description: req.Description,
+		Website:     req.Website,
+		Email:       req.Email,
+		Password:    hashedPassword,
+		Role:        models.RoleCompany,
+		Locale:      string(mail.ParseLanguage(req.Locale)),
+	}
+
+	if err := s.companyRepo.Create(&company); err != nil {
+		return dto.CompanyResponse{}, err
+	}
+
+	return dto.CompanyResponse{
+		Base:        company.Base,
+		Name:        company.Name,
+		Description: company.Description,
+		Website:     company.Website,
+		Email:       company.Email,
+		Role:        company.Role,
+	}, nil
+}
+
+func (s *authService) LoginCompany(ctx context.Context, req dto.CompanyLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error) {
+	company, err := s.companyRepo.GetByEmail(ctx, s.db, req.Email)
+	if err != nil {
+		return dto.TokenResponse{}, dto.ErrEmailNotFound
+	}
+
+	isValid, err := helpers.CheckPassword(company.Password, []byte(req.Password))
+	if err != nil || !isValid {
+		return dto.TokenResponse{}, constants.ErrInvalidCredentials
+	}
+
+	return s.issueTokens(ctx, company.ID, models.RoleCompany, client)
+}
+
+// LoginCompanyMember — вход сотрудника компании. Пока приглашение
+// не принято, пароля у сотрудника нет и войти нельзя.
+func (s *authService) LoginCompanyMember(ctx context.Context, req dto.CompanyLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error) {
+	member, err := s.memberRepo.GetByEmail(ctx, s.db, strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		return dto.TokenResponse{}, dto.ErrEmailNotFound
+	}
+
+	if member.Status != models.MemberActive {
+		return dto.TokenResponse{}, constants.ErrInvalidCredentials
+	}
+
+	isValid, err := helpers.CheckPassword(member.Password, []byte(req.Password))
+	if err != nil || !isValid {
+		return dto.TokenResponse{}, constants.ErrInvalidCredentials
+	}
+
+	return s.issueTokens(ctx, member.ID, models.RoleCompanyMember, client)
+}
+
+// AcceptInvitation принимает приглашение в команду: сотрудник задаёт
+// имя и пароль и сразу входит в систему.
+func (s *authService) AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest, client dto.ClientInfo) (dto.TokenResponse, error) {
+	hashedPassword, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	var response dto.TokenResponse
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeOneTimeToken(ctx, tx, s.oneTimeTokenRepository, req.Token, models.TokenPurposeMemberInvitation)
+		if err != nil {
+			return err
+		}
+
+		member, err := s.memberRepo.Activate(ctx, tx, token.UserID, strings.TrimSpace(req.Name), hashedPassword)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrTokenInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		response, err = s.openSession(ctx, tx, member.ID, models.RoleCompanyMember, client)
+		return err
+	})
+
+	return response, err
+}
+
+// LoginAdmin — вход администратора платформы.
+func (s *authService) LoginAdmin(ctx context.Context, req dto.CompanyLoginRequest, client dto.ClientInfo) (dto.TokenResponse, error) {
+	admin, err := s.adminRepo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		return dto.TokenResponse{}, constants.ErrInvalidCredentials
+	}
+
+	isValid, err := helpers.CheckPassword(admin.Password, []byte(req.Password))
+	if err != nil || !isValid {
+		return dto.TokenResponse{}, constants.ErrInvalidCredentials
+	}
+
+	return s.issueTokens(ctx, admin.ID, models.RoleAdmin, client)
+}
+
+// IsBlocked сообщает, заблокирован ли субъект администратором.
+func (s *authService) IsBlocked(ctx context.Context, userId uint, role string) (bool, error) {
+	return s.adminRepo.IsBlocked(ctx, userId, role)
+}
+
+// RecordImpersonated записывает в журнал администратора изменение,
+// сделанное им под пользователем. Ошибка записи не отменяет запрос.
+func (s *authService) RecordImpersonated(ctx context.Context, adminId uint, role string, userId uint, request string) {
+	err := s.adminRepo.Record(ctx, &models.AdminAction{
+		AdminID:    adminId,
+		Action:     models.AdminActionImpersonated,
+		TargetRole: role,
+		TargetID:   userId,
+		Reason:     request,
+	})
+	if err != nil {
+		s.logger.Error("не удалось записать действие под пользователем",
+			slog.Uint64("admin_id", uint64(adminId)),
+			slog.String("request", request),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// issueTokens открывает новую сессию и выдаёт для неё пару access/refresh токенов.
+// Заблокированный пользователь войти не может.
+func (s *authService) issueTokens(ctx context.Context, userId uint, role string, client dto.ClientInfo) (dto.TokenResponse, error) {
+	blocked, err := s.adminRepo.IsBlocked(ctx, userId, role)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	if blocked {
+		return dto.TokenResponse{}, constants.ErrAccountBlocked
+	}
+
+	var response dto.TokenResponse
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		response, err = s.openSession(ctx, tx, userId, role, client)
+		return err
+	})
+
+	return response, err
+}
+
+func (s *authService) openSession(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId uint,
+	role string,
+	client dto.ClientInfo,
+) (dto.TokenResponse, error) {
 	refreshTokenString, expiresAt := s.jwtService.GenerateRefreshToken()
 
+	device := client.Device
+	if device == "" {
+		device = deviceName(client.UserAgent)
+	}
+	if runes := []rune(device); len(runes) > maxDeviceNameLength {
+		device = string(runes[:maxDeviceNameLength])
+	}
+
+	session, err := s.sessionRepository.Create(ctx, tx, models.Session{
+		ID:         uuid.New(),
+		UserID:     userId,
+		Role:       role,
+		Device:     device,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		LastUsedAt: time.Now(),
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return s.createRefreshToken(ctx, tx, userId, role, session.ID, refreshTokenString, expiresAt)
+}
+
+func (s *authService) createRefreshToken(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId uint,
+	role string,
+	sessionId uuid.UUID,
+	refreshTokenString string,
+	expiresAt time.Time,
+) (dto.TokenResponse, error) {
 	refreshToken := models.RefreshToken{
 		ID:        uuid.New(),
-		UserID:    user.ID,
+		UserID:    userId,
+		Role:      role,
 		Token:     refreshTokenString,
 		ExpiresAt: expiresAt,
+		SessionID: &sessionId,
 	}
 
-	_, err = s.refreshTokenRepository.Create(ctx, s.db, refreshToken)
-	if err != nil {
+	if _, err := s.refreshTokenRepository.Create(ctx, tx, refreshToken); err != nil {
 		return dto.TokenResponse{}, err
 	}
 
 	return dto.TokenResponse{
-		AccessToken:  accessToken,
+		AccessToken:  s.jwtService.GenerateSessionAccessToken(userId, role, sessionId.String()),
 		RefreshToken: refreshTokenString,
-		Role:         user.Role,
+		Role:         role,
 	}, nil
 }
 
-func (s *authService) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.TokenResponse, error) {
+// RefreshToken меняет refresh-токен на новый в рамках той же сессии.
+// Использованный токен не удаляется, а помечается: если его предъявят
+// повторно, токен, скорее всего, украден, и сессия отзывается целиком.
+func (s *authService) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest, client dto.ClientInfo) (dto.TokenResponse, error) {
 	refreshToken, err := s.refreshTokenRepository.FindByToken(ctx, s.db, req.RefreshToken)
 	if err != nil {
 		return dto.TokenResponse{}, constants.ErrRefreshTokenNotFound
 	}
 
-	accessToken := s.jwtService.GenerateAccessToken(refreshToken.UserID, refreshToken.User.Role)
-	newRefreshTokenString, expiresAt := s.jwtService.GenerateRefreshToken()
+	if refreshToken.RotatedAt != nil {
+		s.revokeFamily(ctx, refreshToken)
+		return dto.TokenResponse{}, constants.ErrRefreshTokenReused
+	}
+
+	if refreshToken.ExpiresAt.Before(time.Now()) {
+		if err := s.refreshTokenRepository.DeleteByToken(ctx, s.db, req.RefreshToken); err != nil {
+			return dto.TokenResponse{}, err
+		}
+		return dto.TokenResponse{}, constants.ErrRefreshTokenExpired
+	}
 
-	err = s.refreshTokenRepository.DeleteByToken(ctx, s.db, req.RefreshToken)
+	blocked, err := s.adminRepo.IsBlocked(ctx, refreshToken.UserID, refreshToken.Role)
 	if err != nil {
 		return dto.TokenResponse{}, err
 	}
+	if blocked {
+		return dto.TokenResponse{}, constants.ErrAccountBlocked
+	}
 
-	newRefreshToken := models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    refreshToken.UserID,
-		Token:     newRefreshTokenString,
-		ExpiresAt: expiresAt,
+	if refreshToken.SessionID != nil {
+		session, err := s.sessionRepository.FindByID(ctx, s.db, *refreshToken.SessionID)
+		if err != nil || session.RevokedAt != nil {
+			return dto.TokenResponse{}, constants.ErrRefreshTokenNotFound
+		}
 	}
 
-	_, err = s.refreshTokenRepository.Create(ctx, s.db, newRefreshToken)
+	var response dto.TokenResponse
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		rotated, err := s.refreshTokenRepository.MarkRotated(ctx, tx, refreshToken.ID)
+		if err != nil {
+			return err
+		}
+		if !rotated {
+			return constants.ErrRefreshTokenReused
+		}
+
+		// Токены, выданные до появления сессий, переводим в новую сессию.
+		if refreshToken.SessionID == nil {
+			response, err = s.openSession(ctx, tx, refreshToken.UserID, refreshToken.Role, client)
+			return err
+		}
+
+		newRefreshTokenString, expiresAt := s.jwtService.GenerateRefreshToken()
+		if err := s.sessionRepository.Touch(ctx, tx, *refreshToken.SessionID, client.UserAgent, client.IP, expiresAt); err != nil {
+			return err
+		}
+
+		response, err = s.createRefreshToken(
+			ctx, tx, refreshToken.UserID, refreshToken.Role, *refreshToken.SessionID, newRefreshTokenString, expiresAt,
+		)
+		return err
+	})
+	if errors.Is(err, constants.ErrRefreshTokenReused) {
+		s.revokeFamily(ctx, refreshToken)
+	}
 	if err != nil {
 		return dto.TokenResponse{}, err
 	}
 
-	return dto.TokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: newRefreshTokenString,
-		Role:         refreshToken.User.Role,
-	}, nil
+	return response, nil
+}
+
+// revokeFamily отзывает сессию, к которой относится повторно предъявленный токен.
+func (s *authService) revokeFamily(ctx context.Context, refreshToken models.RefreshToken) {
+	s.logger.Warn("повторное использование refresh-токена, сессия отозвана",
+		slog.Uint64("user_id", uint64(refreshToken.UserID)),
+		slog.String("role", refreshToken.Role),
+		slog.Any("session_id", refreshToken.SessionID),
+	)
+
+	if refreshToken.SessionID == nil {
+		if err := s.refreshTokenRepository.DeleteByToken(ctx, s.db, refreshToken.Token); err != nil {
+			s.logger.Error("не удалось удалить refresh-токен", slog.Any("error", err))
+		}
+		return
+	}
+
+	if err := s.revokeSession(ctx, *refreshToken.SessionID, models.SessionRevokedByReuse); err != nil {
+		s.logger.Error("не удалось отозвать сессию", slog.Any("error", err))
+	}
+}
+
+func (s *authService) revokeSession(ctx context.Context, sessionId uuid.UUID, reason string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.sessionRepository.Revoke(ctx, tx, sessionId, reason); err != nil {
+			return err
+		}
+		return s.refreshTokenRepository.DeleteBySessionID(ctx, tx, sessionId)
+	})
+}
+
+// Logout завершает все сессии пользователя.
+func (s *authService) Logout(ctx context.Context, userId uint, role string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.sessionRepository.RevokeByUserID(ctx, tx, userId, role, models.SessionRevokedByLogout); err != nil {
+			return err
+		}
+		return s.refreshTokenRepository.DeleteByUserID(ctx, tx, userId, role)
+	})
+}
+
+func (s *authService) ListSessions(ctx context.Context, userId uint, role string, currentSessionId string) ([]models.Session, error) {
+	sessions, err := s.sessionRepository.ListActive(ctx, s.db, userId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID.String() == currentSessionId
+	}
+
+	return sessions, nil
 }
 
-func (s *authService) Logout(ctx context.Context, userId string) error {
-	return s.refreshTokenRepository.DeleteByUserID(ctx, s.db, userId)
+// RevokeSession завершает одну сессию. Уже выданный access-токен
+// продолжает действовать до истечения своего короткого срока.
+func (s *authService) RevokeSession(ctx context.Context, userId uint, role string, sessionId uuid.UUID) error {
+	session, err := s.sessionRepository.FindByID(ctx, s.db, sessionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrSessionNotFound
+		}
+		return err
+	}
+
+	// Чужая сессия неотличима от несуществующей.
+	if session.UserID != userId || session.Role != role || session.RevokedAt != nil {
+		return constants.ErrSessionNotFound
+	}
+
+	return s.revokeSession(ctx, sessionId, models.SessionRevokedByUser)
 }
 
 func (s *authService) SendVerificationEmail(ctx context.Context, req dto.SendVerificationEmailRequest) error {
@@ -171,23 +533,30 @@ func (s *authService) SendVerificationEmail(ctx context.Context, req dto.SendVer
 		return dto.ErrAccountAlreadyVerified
 	}
 
-	verificationToken := s.jwtService.GenerateAccessToken(user.ID, "verification")
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		token, err := issueOneTimeToken(ctx, tx, s.oneTimeTokenRepository, user.ID, user.Role, models.TokenPurposeEmailVerification, emailVerificationTTL)
+		if err != nil {
+			return err
+		}
 
-	subject := "Email Verification"
-	body := "Please verify your email using this token: " + verificationToken
-
-	return utils.SendMail(user.Email, subject, body)
+		link := appLink("/verify-email", token)
+		return s.notificationService.EmailVerification(ctx, tx, user, link, int(emailVerificationTTL.Hours()))
+	})
 }
 
 func (s *authService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (dto.VerifyEmailResponse, error) {
-	token, err := s.jwtService.ValidateToken(req.Token)
-	if err != nil || !token.Valid {
-		return dto.VerifyEmailResponse{}, dto.ErrTokenInvalid
-	}
-
-	userId, err := s.jwtService.GetUserIDByToken(req.Token)
+	var userId uint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeOneTimeToken(ctx, tx, s.oneTimeTokenRepository, req.Token, models.TokenPurposeEmailVerification)
+		if err != nil {
+			return err
+		}
+		userId = token.UserID
+
+		return s.applicantRepo.SetVerified(ctx, tx, userId)
+	})
 	if err != nil {
-		return dto.VerifyEmailResponse{}, dto.ErrTokenInvalid
+		return dto.VerifyEmailResponse{}, err
 	}
 
 	user, err := s.applicantRepo.GetByID(userId)
@@ -195,15 +564,9 @@ func (s *authService) VerifyEmail(ctx context.Context, req dto.VerifyEmailReques
 		return dto.VerifyEmailResponse{}, dto.ErrUserNotFound
 	}
 
-	user.IsVerified = true
-	updatedUser, err := s.applicantRepo.Update(userId, user)
-	if err != nil {
-		return dto.VerifyEmailResponse{}, err
-	}
-
 	return dto.VerifyEmailResponse{
-		Email:      updatedUser.Email,
-		IsVerified: updatedUser.IsVerified,
+		Email:      user.Email,
+		IsVerified: user.IsVerified,
 	}, nil
 }
 
@@ -213,45 +576,225 @@ func (s *authService) SendPasswordReset(ctx context.Context, req dto.SendPasswor
 		return dto.ErrEmailNotFound
 	}
 
-	resetToken := s.jwtService.GenerateAccessToken(user.ID, "password_reset")
-
-	subject := "Password Reset"
-	body := "Please reset your password using this token: " + resetToken
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		token, err := issueOneTimeToken(ctx, tx, s.oneTimeTokenRepository, user.ID, user.Role, models.TokenPurposePasswordReset, passwordResetTTL)
+		if err != nil {
+			return err
+		}
 
-	return utils.SendMail(user.Email, subject, body)
+		link := appLink("/reset-password", token)
+		return s.notificationService.PasswordReset(ctx, tx, user, link, int(passwordResetTTL.Hours()))
+	})
 }
 
+// ResetPassword меняет пароль и завершает все сессии пользователя:
+// тот, кто знал старый пароль, не должен остаться в системе.
 func (s *authService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
-	token, err := s.jwtService.ValidateToken(req.Token)
-	if err != nil || !token.Valid {
-		return constants.ErrPasswordResetToken
+	hashedPassword, err := helpers.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
 	}
 
-	userId, err := s.jwtService.GetUserIDByToken(req.Token)
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		token, err := consumeOneTimeToken(ctx, tx, s.oneTimeTokenRepository, req.Token, models.TokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+
+		if err := s.applicantRepo.UpdatePassword(ctx, tx, token.UserID, hashedPassword); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return dto.ErrUserNotFound
+			}
+			return err
+		}
+
+		err = s.sessionRepository.RevokeByUserID(ctx, tx, token.UserID, token.Role, models.SessionRevokedByPasswordReset)
+		if err != nil {
+			return err
+		}
+		return s.refreshTokenRepository.DeleteByUserID(ctx, tx, token.UserID, token.Role)
+	})
+}
+
+// IssueStreamTicket выдаёт одноразовый билет для /events. EventSource
+// не умеет передавать заголовки, а access-токен в адресе попал бы
+// в журналы запросов; билет живёт полминуты и гасится при подключении.
+// Билеты других вкладок остаются действительными.
+func (s *authService) IssueStreamTicket(ctx context.Context, userId uint, role string) (string, error) {
+	return createOneTimeToken(ctx, s.db, s.oneTimeTokenRepository, userId, role, models.TokenPurposeEventStream, streamTicketTTL)
+}
+
+// ConsumeStreamTicket гасит билет и возвращает его владельца.
+// Заблокированный пользователь поток не получает.
+func (s *authService) ConsumeStreamTicket(ctx context.Context, ticket string) (uint, string, error) {
+	token, err := consumeOneTimeToken(ctx, s.db, s.oneTimeTokenRepository, ticket, models.TokenPurposeEventStream)
 	if err != nil {
-		return constants.ErrPasswordResetToken
+		return 0, "", err
 	}
 
-	user, err := s.applicantRepo.GetByID(userId)
+	blocked, err := s.adminRepo.IsBlocked(ctx, token.UserID, token.Role)
 	if err != nil {
-		return dto.ErrUserNotFound
+		return 0, "", err
+	}
+	if blocked {
+		return 0, "", constants.ErrAccountBlocked
 	}
 
-	hashedPassword, err := helpers.HashPassword(req.NewPassword)
-	fmt.Println(hashedPassword)
+	return token.UserID, token.Role, nil
+}
+
+// issueOneTimeToken выдаёт токен для письма; предыдущие токены того же
+// назначения перестают действовать.
+func issueOneTimeToken(
+	ctx context.Context,
+	tx *gorm.DB,
+	tokens repository.OneTimeTokenRepository,
+	userId uint,
+	role string,
+	purpose string,
+	ttl time.Duration,
+) (string, error) {
+	if err := tokens.DeleteByUserID(ctx, tx, userId, role, purpose); err != nil {
+		return "", err
+	}
+	return createOneTimeToken(ctx, tx, tokens, userId, role, purpose, ttl)
+}
+
+// createOneTimeToken сохраняет хеш нового токена и возвращает сам токен.
+func createOneTimeToken(
+	ctx context.Context,
+	tx *gorm.DB,
+	tokens repository.OneTimeTokenRepository,
+	userId uint,
+	role string,
+	purpose string,
+	ttl time.Duration,
+) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	_, err := tokens.Create(ctx, tx, models.OneTimeToken{
+		ID:        uuid.New(),
+		TokenHash: hashToken(token),
+		Purpose:   purpose,
+		UserID:    userId,
+		Role:      role,
+		ExpiresAt: time.Now().Add(ttl),
+	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// user.Password = hashedPassword
-	_, err = s.applicantRepo.Update(userId, user)
+	return token, nil
+}
+
+// consumeOneTimeToken гасит токен в транзакции tx: если дальнейшие
+// изменения откатятся, токен останется действительным.
+func consumeOneTimeToken(
+	ctx context.Context,
+	tx *gorm.DB,
+	tokens repository.OneTimeTokenRepository,
+	token string,
+	purpose string,
+) (models.OneTimeToken, error) {
+	record, err := tokens.FindByHash(ctx, tx, hashToken(token), purpose)
 	if err != nil {
-		return err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.OneTimeToken{}, dto.ErrTokenInvalid
+		}
+		return models.OneTimeToken{}, err
+	}
+
+	if record.ConsumedAt != nil {
+		return models.OneTimeToken{}, dto.ErrTokenInvalid
 	}
+	if record.ExpiresAt.Before(time.Now()) {
+		return models.OneTimeToken{}, dto.ErrTokenExpired
+	}
+
+	consumed, err := tokens.Consume(ctx, tx, record.ID)
+	if err != nil {
+		return models.OneTimeToken{}, err
+	}
+	if !consumed {
+		return models.OneTimeToken{}, dto.ErrTokenInvalid
+	}
+
+	return record, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// appLink строит ссылку на страницу фронтенда с одноразовым токеном.
+func appLink(path string, token string) string {
+	return appURL(path) + "?token=" + url.QueryEscape(token)
+}
 
-	return nil
+// appURL строит адрес страницы фронтенда из APP_URL.
+func appURL(path string) string {
+	base := os.Getenv("APP_URL")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return strings.TrimRight(base, "/") + path
 }
 
 func (s *authService) GetJWTService() *JWTService {
 	return &s.jwtService
 }
+
+// deviceName описывает устройство по User-Agent, например «Chrome, Windows».
+func deviceName(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+
+	var platform string
+	for _, p := range []struct{ marker, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, p.marker) {
+			platform = p.name
+			break
+		}
+	}
+
+	var browser string
+	for _, b := range []struct{ marker, name string }{
+		{"YaBrowser/", "Yandex Browser"},
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	} {
+		if strings.Contains(userAgent, b.marker) {
+			browser = b.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + ", " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	}
+
+	// Не браузер (мобильное приложение, curl и т. п.) — берём имя продукта.
+	product, _, _ := strings.Cut(userAgent, "/")
+	return strings.TrimSpace(product)
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
+)
+
+// accessMode — читает субъект отклик (вакансию) или ведёт его.
+type accessMode int
+
+const (
+	accessRead accessMode = iota
+	accessWrite
+)
+
+// companyAccess проверяет права на отклики и вакансии компании.
+// Компания действует от своего имени, сотрудник — от имени своей
+// компании по роли в команде: наблюдатель только читает, рекрутер ведёт
+// назначенные ему вакансии, владелец и администратор — любые.
+type companyAccess struct {
+	members repository.MemberRepository
+}
+
+// checkVacancy проверяет, что субъект действует от имени компании,
+// которой принадлежит вакансия.
+func (a companyAccess) checkVacancy(
+	ctx context.Context,
+	vacancy *models.Vacancy,
+	userId uint,
+	role string,
+	mode accessMode,
+) error {
+	switch role {
+	case models.RoleCompany:
+		if vacancy.CompanyID == userId {
+			return nil
+		}
+	case models.RoleCompanyMember:
+		member, err := a.members.GetByID(ctx, nil, userId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrForbidden
+		}
+		if err != nil {
+			return err
+		}
+		if member.CompanyID != vacancy.CompanyID || member.Status != models.MemberActive {
+			return constants.ErrForbidden
+		}
+		return a.checkMemberRole(ctx, member, vacancy.ID, mode)
+	}
+	return constants.ErrForbidden
+}
+
+func (a companyAccess) checkMemberRole(
+	ctx context.Context,
+	member models.CompanyMember,
+	vacancyId uint,
+	mode accessMode,
+) error {
+	if mode == accessRead {
+		return nil
+	}
+
+	switch member.Role {
+	case models.MemberOwner, models.MemberAdmin:
+		return nil
+	case models.MemberRecruiter:
+		assigned, err := a.members.IsAssigned(ctx, vacancyId, member.ID)
+		if err != nil {
+			return err
+		}
+		if assigned {
+			return nil
+		}
+	}
+	return constants.ErrForbidden
+}
+
+// checkApplication проверяет, что субъект — сторона отклика: соискатель —
+// владелец резюме, компания и её сотрудники — владелец вакансии.
+// app должен быть загружен с вакансией и резюме.
+func (a companyAccess) checkApplication(
+	ctx context.Context,
+	app *models.Application,
+	userId uint,
+	role string,
+	mode accessMode,
+) error {
+	if app.Vacancy == nil || app.Resume == nil {
+		return constants.ErrForbidden
+	}
+
+	if role == models.RoleApplicant {
+		if app.Resume.ApplicantID == userId {
+			return nil
+		}
+		return constants.ErrForbidden
+	}
+	return a.checkVacancy(ctx, app.Vacancy, userId, role, mode)
+}
+
+// companyOf возвращает компанию, от имени которой действует субъект
+// с ролью компании или сотрудника.
+func (a companyAccess) companyOf(ctx context.Context, userId uint, role string) (uint, error) {
+	if role != models.RoleCompanyMember {
+		return userId, nil
+	}
+
+	member, err := a.members.GetByID(ctx, nil, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && member.Status != models.MemberActive {
+		return 0, constants.ErrForbidden
+	}
+	if err != nil {
+		return 0, err
+	}
+	return member.CompanyID, nil
+}
+
+// applicationSide возвращает сторону отклика, от имени которой действует
+// субъект: сотрудник выступает за свою компанию.
+func applicationSide(app *models.Application, userId uint, role string) (uint, string) {
+	if role == models.RoleCompanyMember {
+		return app.Vacancy.CompanyID, models.RoleCompany
+	}
+	return userId, role
+}
@@ -1,51 +1,182 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/events"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/pkg/utils"
 	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
 )
 
+// companyImagesDir — каталог изображений компаний внутри utils.PATH.
+const companyImagesDir = "companies"
+
+// companyImageLimits — предельный размер изображений профиля.
+var companyImageLimits = map[models.CompanyImage]int64{
+	models.CompanyLogo:  2 << 20,
+	models.CompanyCover: 5 << 20,
+}
+
+// companyImageTypes — допустимые типы изображений и расширения файлов.
+// Тип определяется по содержимому, а не по имени файла.
+var companyImageTypes = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpg",
+	"image/webp": "webp",
+}
+
 type CompanyService interface {
 	List() ([]models.Company, error)
+	Get(id uint) (*models.Company, error)
 	Create(models.CompanyCreateRequest) (*models.Company, error)
-	GetVacanciesByCompanyId(uint) ([]models.Vacancy, error)
+	Update(id uint, req models.CompanyUpdateRequest) (*models.Company, error)
+	UploadImage(id uint, kind models.CompanyImage, file *multipart.FileHeader) (*models.Company, error)
+	SetVerified(id uint, verified bool) (*models.Company, error)
+	Delete(id uint) error
+	GetVacanciesByCompanyId(id uint, status models.VacancyStatus) ([]models.Vacancy, error)
 	Applications(uint, models.ApplicationFilter) ([]models.Application, error)
-	AcceptApplication(uint, uint) error
-	RejectApplication(uint, uint) error
+	AcceptApplication(companyId uint, appId uint, actor models.CompanyActor) error
+	RejectApplication(companyId uint, appId uint, actor models.CompanyActor) error
+	ChangeApplicationStatus(
+		companyId uint,
+		appId uint,
+		actor models.CompanyActor,
+		req models.ChangeApplicationStatusRequest,
+	) (*models.Application, error)
 }
 
 type companyService struct {
 	companyRepo     repository.CompanyRepository
 	vacancyRepo     repository.VacancyRepository
 	applicationRepo repository.ApplicationRepository
+	memberRepo      repository.MemberRepository
+	notifications   NotificationService
+	interviews      InterviewService
+	broker          events.Broker
 }
 
 func NewCompanyService(
 	companyRepo repository.CompanyRepository,
 	vacancyRepo repository.VacancyRepository,
 	applicationRepo repository.ApplicationRepository,
+	memberRepo repository.MemberRepository,
+	notifications NotificationService,
+	interviews InterviewService,
+	broker events.Broker,
 ) CompanyService {
 	return &companyService{
 		companyRepo:     companyRepo,
 		vacancyRepo:     vacancyRepo,
 		applicationRepo: applicationRepo,
+		memberRepo:      memberRepo,
+		notifications:   notifications,
+		interviews:      interviews,
+		broker:          broker,
 	}
 }
 
-func (s *companyService) RejectApplication(companyId uint, appId uint) error {
-	_, err := s.companyRepo.Get(companyId)
+func (s *companyService) RejectApplication(companyId uint, appId uint, actor models.CompanyActor) error {
+	req := models.ChangeApplicationStatusRequest{Status: models.StatusRejected}
+	_, err := s.ChangeApplicationStatus(companyId, appId, actor, req)
+	return err
+}
+
+func (s *companyService) AcceptApplication(companyId uint, appId uint, actor models.CompanyActor) error {
+	req := models.ChangeApplicationStatusRequest{Status: models.StatusAccepted}
+	_, err := s.ChangeApplicationStatus(companyId, appId, actor, req)
+	return err
+}
+
+// ChangeApplicationStatus двигает отклик по статусам от имени компании.
+// Отзыв отклика доступен только соискателю.
+func (s *companyService) ChangeApplicationStatus(
+	companyId uint,
+	appId uint,
+	actor models.CompanyActor,
+	req models.ChangeApplicationStatusRequest,
+) (*models.Application, error) {
+	if req.Status == models.StatusWithdrawn {
+		return nil, constants.ErrForbidden
+	}
+
+	current, err := s.checkApplicationOwner(companyId, appId)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return s.applicationRepo.RejectApplication(appId)
+
+	if err := s.checkRecruiter(actor, current.VacancyID); err != nil {
+		return nil, err
+	}
+
+	var changed *models.ApplicationStatusChange
+	notify := func(tx *gorm.DB, app *models.Application, change *models.ApplicationStatusChange) error {
+		changed = change
+		if err := s.notifications.ApplicationStatusChanged(context.Background(), tx, app, change); err != nil {
+			return err
+		}
+		return s.interviews.ApplicationClosed(context.Background(), tx, app, change)
+	}
+	app, err := s.applicationRepo.ChangeStatus(
+		appId, req.Status, actor.UserID(companyId), actor.UserRole(), req.Comment, notify,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.broker.Publish(context.Background(),
+		applicationStatusChangedEvent(app, changed),
+		applicationRecipients(current.Resume.ApplicantID, companyId)...,
+	)
+
+	return app, nil
 }
 
-func (s *companyService) AcceptApplication(companyId uint, appId uint) error {
+// checkApplicationOwner убеждается, что отклик подан на вакансию этой компании.
+// Возвращает отклик с вакансией и резюме.
+func (s *companyService) checkApplicationOwner(companyId uint, appId uint) (*models.Application, error) {
 	_, err := s.companyRepo.Get(companyId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	app, err := s.applicationRepo.GetByID(appId)
+	if err != nil {
+		return nil, err
+	}
+
+	if app.Vacancy == nil || app.Vacancy.CompanyID != companyId || app.Resume == nil {
+		return nil, constants.ErrForbidden
+	}
+
+	return app, nil
+}
+
+// checkRecruiter проверяет, что участник команды может вести отклики
+// на вакансию: владельцы и администраторы ведут все, рекрутер — только
+// назначенные ему, наблюдатель — никакие.
+func (s *companyService) checkRecruiter(actor models.CompanyActor, vacancyId uint) error {
+	switch actor.Role {
+	case models.MemberOwner, models.MemberAdmin:
+		return nil
+	case models.MemberRecruiter:
+		assigned, err := s.memberRepo.IsAssigned(context.Background(), vacancyId, actor.MemberID)
+		if err != nil {
+			return err
+		}
+		if assigned {
+			return nil
+		}
 	}
-	return s.applicationRepo.AcceptApplication(appId)
+	return constants.ErrForbidden
 }
 
 func (s *companyService) Applications(id uint, filter models.ApplicationFilter) ([]models.Application, error) {
@@ -56,8 +187,8 @@ func (s *companyService) Applications(id uint, filter models.ApplicationFilter)
 	return s.applicationRepo.Applications(id, filter)
 }
 
-func (s *companyService) GetVacanciesByCompanyId(id uint) ([]models.Vacancy, error) {
-	return s.vacancyRepo.GetByCompanyId(id)
+func (s *companyService) GetVacanciesByCompanyId(id uint, status models.VacancyStatus) ([]models.Vacancy, error) {
+	return s.vacancyRepo.GetByCompanyId(id, status)
 }
 
 func (s *companyService) List() ([]models.Company, error) {
@@ -76,3 +207,119 @@ func (s *companyService) Create(dto models.CompanyCreateRequest) (*models.Compan
 
 	return company, nil
 }
+
+func (s *companyService) Get(id uint) (*models.Company, error) {
+	return s.companyRepo.Get(id)
+}
+
+func (s *companyService) Update(id uint, req models.CompanyUpdateRequest) (*models.Company, error) {
+	return s.companyRepo.Update(id, func(company *models.Company) error {
+		if req.Name != nil {
+			company.Name = strings.TrimSpace(*req.Name)
+		}
+		if req.Description != nil {
+			company.Description = *req.Description
+		}
+		if req.Website != nil {
+			company.Website = strings.TrimSpace(*req.Website)
+		}
+		if req.Locale != nil {
+			company.Locale = *req.Locale
+		}
+		if req.Industry != nil {
+			company.Industry = strings.TrimSpace(*req.Industry)
+		}
+		if req.Size != nil {
+			company.Size = *req.Size
+		}
+		if req.Headquarters != nil {
+			company.Headquarters = strings.TrimSpace(*req.Headquarters)
+		}
+		if req.SocialLinks != nil {
+			company.SocialLinks = *req.SocialLinks
+		}
+		return nil
+	})
+}
+
+// UploadImage сохраняет логотип или обложку компании в utils.PATH
+// и удаляет предыдущий файл.
+func (s *companyService) UploadImage(id uint, kind models.CompanyImage, file *multipart.FileHeader) (*models.Company, error) {
+	limit, ok := companyImageLimits[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown company image %q", kind)
+	}
+	if file.Size > limit {
+		return nil, constants.ErrImageTooLarge
+	}
+
+	ext, err := sniffCompanyImage(file)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%d-%s-%d.%s", companyImagesDir, id, kind, time.Now().UnixNano(), ext)
+	if err := utils.UploadFile(file, path); err != nil {
+		return nil, err
+	}
+
+	var previous string
+	company, err := s.companyRepo.Update(id, func(company *models.Company) error {
+		url := "/" + utils.PATH + "/" + path
+		if kind == models.CompanyLogo {
+			previous, company.LogoURL = company.LogoURL, url
+		} else {
+			previous, company.CoverURL = company.CoverURL, url
+		}
+		return nil
+	})
+	if err != nil {
+		_ = utils.DeleteFile(path)
+		return nil, err
+	}
+
+	if old, ok := strings.CutPrefix(previous, "/"+utils.PATH+"/"); ok {
+		_ = utils.DeleteFile(old)
+	}
+
+	return company, nil
+}
+
+func sniffCompanyImage(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", constants.ErrUnsupportedImage
+	}
+
+	ext, ok := companyImageTypes[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", constants.ErrUnsupportedImage
+	}
+	return ext, nil
+}
+
+func (s *companyService) SetVerified(id uint, verified bool) (*models.Company, error) {
+	return s.companyRepo.Update(id, func(company *models.Company) error {
+		if company.Verified == verified {
+			return nil
+		}
+		company.Verified = verified
+		company.VerifiedAt = nil
+		if verified {
+			now := time.Now()
+			company.VerifiedAt = &now
+		}
+		return nil
+	})
+}
+
+func (s *companyService) Delete(id uint) error {
+	return s.companyRepo.Delete(id)
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/events"
+	"github.com/AliUmarov/team-find-me-job/internal/ical"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
+)
+
+// InterviewService — слоты собеседований компании и запись на них
+// по откликам. Слоты и собеседования компании ведут и её сотрудники.
+type InterviewService interface {
+	CreateSlot(ctx context.Context, userId uint, role string, vacancyId uint, req models.CreateInterviewSlotRequest) (*models.InterviewSlot, error)
+	Slots(ctx context.Context, userId uint, role string, vacancyId uint, filter models.InterviewSlotFilter) ([]models.InterviewSlot, error)
+	DeleteSlot(ctx context.Context, userId uint, role string, vacancyId uint, slotId uint) error
+
+	AvailableSlots(ctx context.Context, userId uint, role string, appId uint) ([]models.InterviewSlot, error)
+	Get(ctx context.Context, userId uint, role string, appId uint) (*models.Interview, error)
+	Book(ctx context.Context, applicantId uint, appId uint, req models.BookInterviewRequest) (*models.Interview, error)
+	Reschedule(ctx context.Context, userId uint, role string, appId uint, req models.BookInterviewRequest) (*models.Interview, error)
+	Cancel(ctx context.Context, userId uint, role string, appId uint, req models.CancelInterviewRequest) (*models.Interview, error)
+	Calendar(ctx context.Context, userId uint, role string, appId uint) (*ical.Event, error)
+
+	// ApplicationClosed отменяет предстоящее собеседование, когда отклик
+	// отклонён или отозван. Вызывается в транзакции смены статуса.
+	ApplicationClosed(ctx context.Context, tx *gorm.DB, app *models.Application, change *models.ApplicationStatusChange) error
+}
+
+type interviewService struct {
+	repo            repository.InterviewRepository
+	applicationRepo repository.ApplicationRepository
+	vacancyRepo     repository.VacancyRepository
+	companyRepo     repository.CompanyRepository
+	applicantRepo   repository.ApplicantRepository
+	access          companyAccess
+	notifications   NotificationService
+	broker          events.Broker
+}
+
+func NewInterviewService(
+	repo repository.InterviewRepository,
+	applicationRepo repository.ApplicationRepository,
+	vacancyRepo repository.VacancyRepository,
+	companyRepo repository.CompanyRepository,
+	applicantRepo repository.ApplicantRepository,
+	memberRepo repository.MemberRepository,
+	notifications NotificationService,
+	broker events.Broker,
+) InterviewService {
+	return &interviewService{
+		repo:            repo,
+		applicationRepo: applicationRepo,
+		vacancyRepo:     vacancyRepo,
+		companyRepo:     companyRepo,
+		applicantRepo:   applicantRepo,
+		access:          companyAccess{members: memberRepo},
+		notifications:   notifications,
+		broker:          broker,
+	}
+}
+
+func (s *interviewService) CreateSlot(
+	ctx context.Context,
+	userId uint,
+	role string,
+	vacancyId uint,
+	req models.CreateInterviewSlotRequest,
+) (*models.InterviewSlot, error) {
+	vacancy, err := s.vacancy(ctx, userId, role, vacancyId, accessWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := req.EndsAt.Sub(req.StartsAt)
+	if !req.StartsAt.After(time.Now()) || duration < models.MinInterviewDuration || duration > models.MaxInterviewDuration {
+		return nil, constants.ErrInvalidInterviewSlot
+	}
+
+	slot := &models.InterviewSlot{
+		VacancyID: vacancyId,
+		CompanyID: vacancy.CompanyID,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Location:  req.Location,
+		Note:      req.Note,
+	}
+	if err := s.repo.CreateSlot(ctx, slot); err != nil {
+		return nil, err
+	}
+
+	return slot, nil
+}
+
+func (s *interviewService) Slots(
+	ctx context.Context,
+	userId uint,
+	role string,
+	vacancyId uint,
+	filter models.InterviewSlotFilter,
+) ([]models.InterviewSlot, error) {
+	if _, err := s.vacancy(ctx, userId, role, vacancyId, accessRead); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Slots(ctx, vacancyId, filter)
+}
+
+func (s *interviewService) DeleteSlot(ctx context.Context, userId uint, role string, vacancyId uint, slotId uint) error {
+	if _, err := s.vacancy(ctx, userId, role, vacancyId, accessWrite); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSlot(ctx, vacancyId, slotId)
+}
+
+// AvailableSlots возвращает свободные будущие слоты вакансии отклика.
+func (s *interviewService) AvailableSlots(ctx context.Context, userId uint, role string, appId uint) ([]models.InterviewSlot, error) {
+	app, err := s.application(ctx, userId, role, appId, accessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Slots(ctx, app.VacancyID, models.InterviewSlotFilter{OnlyFree: true})
+}
+
+func (s *interviewService) Get(ctx context.Context, userId uint, role string, appId uint) (*models.Interview, error) {
+	if _, err := s.application(ctx, userId, role, appId, accessRead); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Active(ctx, appId)
+}
+
+func (s *interviewService) Book(
+	ctx context.Context,
+	applicantId uint,
+	appId uint,
+	req models.BookInterviewRequest,
+) (*models.Interview, error) {
+	app, err := s.application(ctx, applicantId, models.RoleApplicant, appId, accessWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	interview := &models.Interview{
+		ApplicationID: app.ID,
+		ApplicantID:   app.Resume.ApplicantID,
+		CompanyID:     app.Vacancy.CompanyID,
+	}
+	// Запись на собеседование переводит просмотренный отклик в статус
+	// interview: о смене статуса сообщаем так же, как при любой другой.
+	var booked *models.Application
+	var changed *models.ApplicationStatusChange
+	onStatus := func(tx *gorm.DB, app *models.Application, change *models.ApplicationStatusChange) error {
+		booked, changed = app, change
+		return s.notifications.ApplicationStatusChanged(ctx, tx, app, change)
+	}
+	err = s.repo.Book(ctx, interview, req.SlotID, s.notify(ctx, models.NotificationInterviewScheduled), onStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed != nil {
+		s.broker.Publish(context.Background(),
+			applicationStatusChangedEvent(booked, changed),
+			applicationRecipients(interview.ApplicantID, interview.CompanyID)...,
+		)
+	}
+
+	return interview, nil
+}
+
+func (s *interviewService) Reschedule(
+	ctx context.Context,
+	userId uint,
+	role string,
+	appId uint,
+	req models.BookInterviewRequest,
+) (*models.Interview, error) {
+	if _, err := s.checkUpcoming(ctx, userId, role, appId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Reschedule(ctx, appId, req.SlotID, s.notify(ctx, models.NotificationInterviewRescheduled))
+}
+
+func (s *interviewService) Cancel(
+	ctx context.Context,
+	userId uint,
+	role string,
+	appId uint,
+	req models.CancelInterviewRequest,
+) (*models.Interview, error) {
+	app, err := s.checkUpcoming(ctx, userId, role, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, side := applicationSide(app, userId, role)
+	return s.repo.Cancel(ctx, nil, appId, side, req.Reason, s.notify(ctx, models.NotificationInterviewCancelled))
+}
+
+// Calendar возвращает приглашение на назначенное собеседование.
+func (s *interviewService) Calendar(ctx context.Context, userId uint, role string, appId uint) (*ical.Event, error) {
+	interview, err := s.Get(ctx, userId, role, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	vacancy, err := s.vacancyRepo.GetByID(interview.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := s.companyRepo.Get(interview.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	applicant, err := s.applicantRepo.GetByID(interview.ApplicantID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := interviewEvent(interview, vacancy, company, ical.Person{Name: applicant.FullName, Email: applicant.Email})
+	return &event, nil
+}
+
+func (s *interviewService) ApplicationClosed(
+	ctx context.Context,
+	tx *gorm.DB,
+	app *models.Application,
+	change *models.ApplicationStatusChange,
+) error {
+	if change.ToStatus != models.StatusRejected && change.ToStatus != models.StatusWithdrawn {
+		return nil
+	}
+
+	interview, err := s.repo.Active(ctx, app.ID)
+	if errors.Is(err, constants.ErrInterviewNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !interview.StartsAt.After(time.Now()) {
+		return nil
+	}
+
+	reason := change.Comment
+	if reason == "" {
+		reason = string(change.ToStatus)
+	}
+	_, err = s.repo.Cancel(ctx, tx, app.ID, change.ChangedByRole, reason, s.notify(ctx, models.NotificationInterviewCancelled))
+	return err
+}
+
+func (s *interviewService) notify(ctx context.Context, notificationType string) repository.InterviewTxHook {
+	return func(tx *gorm.DB, interview *models.Interview) error {
+		return s.notifications.InterviewChanged(ctx, tx, notificationType, interview)
+	}
+}
+
+// checkUpcoming проверяет право вести отклик и что собеседование ещё
+// не началось. Возвращает отклик.
+func (s *interviewService) checkUpcoming(ctx context.Context, userId uint, role string, appId uint) (*models.Application, error) {
+	app, err := s.application(ctx, userId, role, appId, accessWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	interview, err := s.repo.Active(ctx, appId)
+	if err != nil {
+		return nil, err
+	}
+	if !interview.StartsAt.After(time.Now()) {
+		return nil, constants.ErrInterviewStarted
+	}
+
+	return app, nil
+}
+
+func (s *interviewService) application(
+	ctx context.Context,
+	userId uint,
+	role string,
+	appId uint,
+	mode accessMode,
+) (*models.Application, error) {
+	app, err := s.applicationRepo.GetByID(appId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.access.checkApplication(ctx, app, userId, role, mode); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// vacancy проверяет, что субъект действует от имени компании-владельца
+// вакансии, и возвращает вакансию.
+func (s *interviewService) vacancy(
+	ctx context.Context,
+	userId uint,
+	role string,
+	vacancyId uint,
+	mode accessMode,
+) (*models.Vacancy, error) {
+	vacancy, err := s.vacancyRepo.GetByID(vacancyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.access.checkVacancy(ctx, vacancy, userId, role, mode); err != nil {
+		return nil, err
+	}
+
+	return vacancy, nil
+}
+
+// interviewEvent описывает собеседование для календаря: организатор —
+// компания, участник — соискатель. Отменённое собеседование отправляется
+// как отмена встречи с тем же UID.
+func interviewEvent(interview *models.Interview, vacancy *models.Vacancy, company *models.Company, applicant ical.Person) ical.Event {
+	event := ical.Event{
+		UID:       fmt.Sprintf("interview-%d@find-me-job", interview.ID),
+		Sequence:  interview.Sequence,
+		Method:    ical.MethodRequest,
+		Start:     interview.StartsAt,
+		End:       interview.EndsAt,
+		Summary:   vacancy.Title + " — " + company.Name,
+		Location:  interview.Location,
+		Organizer: ical.Person{Name: company.Name, Email: company.Email},
+		Attendees: []ical.Person{applicant},
+	}
+	if interview.Status == models.InterviewCancelled {
+		event.Method = ical.MethodCancel
+	}
+
+	return event
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
-	"os"
 	"strconv"
 	"time"
 
@@ -14,14 +13,23 @@ import (
 
 type JWTService interface {
 	GenerateAccessToken(userId uint, role string) string
+	GenerateSessionAccessToken(userId uint, role string, sessionId string) string
+	GenerateImpersonationToken(userId uint, role string, adminId uint) string
 	GenerateRefreshToken() (string, time.Time)
 	ValidateToken(token string) (*jwt.Token, error)
 	GetUserIDByToken(token string) (uint, error)
+	GetRoleByToken(token string) (string, error)
+	GetSessionIDByToken(token string) (string, error)
+	GetImpersonatorByToken(token string) (uint, error)
 }
 
 type jwtCustomClaim struct {
 	UserID string `json:"user_id"`
 	Role   string `json:"role"`
+	// SessionID есть только у токенов, выданных при входе или ротации.
+	SessionID string `json:"sid,omitempty"`
+	// ImpersonatedBy — ID администратора, вошедшего под пользователем.
+	ImpersonatedBy string `json:"imp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -32,32 +40,42 @@ type jwtService struct {
 	refreshExpiry time.Duration
 }
 
-func NewJWTService() JWTService {
+func NewJWTService(secretKey string) JWTService {
 	return &jwtService{
-		secretKey:     getSecretKey(),
+		secretKey:     secretKey,
 		issuer:        "Template",
 		accessExpiry:  time.Minute * 15,
 		refreshExpiry: time.Hour * 24 * 7,
 	}
 }
 
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "Template"
-	}
-	return secretKey
+func (j *jwtService) GenerateAccessToken(userId uint, role string) string {
+	return j.GenerateSessionAccessToken(userId, role, "")
 }
 
-func (j *jwtService) GenerateAccessToken(userId uint, role string) string {
-	claims := jwtCustomClaim{
-		strconv.Itoa(int(userId)),
-		role,
-		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.accessExpiry)),
-			Issuer:    j.issuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+func (j *jwtService) GenerateSessionAccessToken(userId uint, role string, sessionId string) string {
+	return j.sign(jwtCustomClaim{
+		UserID:    strconv.Itoa(int(userId)),
+		Role:      role,
+		SessionID: sessionId,
+	})
+}
+
+// GenerateImpersonationToken выдаёт access-токен пользователя для входа
+// администратора под ним. Сессии и refresh-токена у такого входа нет.
+func (j *jwtService) GenerateImpersonationToken(userId uint, role string, adminId uint) string {
+	return j.sign(jwtCustomClaim{
+		UserID:         strconv.Itoa(int(userId)),
+		Role:           role,
+		ImpersonatedBy: strconv.Itoa(int(adminId)),
+	})
+}
+
+func (j *jwtService) sign(claims jwtCustomClaim) string {
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.accessExpiry)),
+		Issuer:    j.issuer,
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -104,3 +122,39 @@ func (j *jwtService) GetUserIDByToken(token string) (uint, error) {
 	intId, _ := strconv.Atoi(id)
 	return uint(intId), nil
 }
+
+func (j *jwtService) GetRoleByToken(token string) (string, error) {
+	tToken, err := j.ValidateToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	claims := tToken.Claims.(jwt.MapClaims)
+	role, _ := claims["role"].(string)
+	return role, nil
+}
+
+func (j *jwtService) GetSessionIDByToken(token string) (string, error) {
+	tToken, err := j.ValidateToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	claims := tToken.Claims.(jwt.MapClaims)
+	sessionId, _ := claims["sid"].(string)
+	return sessionId, nil
+}
+
+// GetImpersonatorByToken возвращает ID администратора, вошедшего под
+// пользователем, или 0 для обычного токена.
+func (j *jwtService) GetImpersonatorByToken(token string) (uint, error) {
+	tToken, err := j.ValidateToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	claims := tToken.Claims.(jwt.MapClaims)
+	imp, _ := claims["imp"].(string)
+	adminId, _ := strconv.Atoi(imp)
+	return uint(adminId), nil
+}
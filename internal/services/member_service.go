@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
+)
+
+const memberInvitationTTL = 7 * 24 * time.Hour
+
+// MemberService управляет командой компании: приглашениями, составом
+// и назначением рекрутеров на вакансии.
+type MemberService interface {
+	Invite(ctx context.Context, companyId uint, actor models.CompanyActor, req models.InviteMemberRequest) (*models.CompanyMember, error)
+	List(ctx context.Context, companyId uint) ([]models.CompanyMember, error)
+	Remove(ctx context.Context, companyId uint, actor models.CompanyActor, memberId uint) error
+	AssignVacancy(ctx context.Context, companyId uint, memberId uint, vacancyId uint) error
+	UnassignVacancy(ctx context.Context, companyId uint, memberId uint, vacancyId uint) error
+	Role(companyId uint, memberId uint) (models.MemberRole, error)
+	CompanyOf(ctx context.Context, memberId uint) (uint, error)
+}
+
+type memberService struct {
+	repo                   repository.MemberRepository
+	companyRepo            repository.CompanyRepository
+	vacancyRepo            repository.VacancyRepository
+	oneTimeTokenRepository repository.OneTimeTokenRepository
+	notifications          NotificationService
+	db                     *gorm.DB
+}
+
+func NewMemberService(
+	repo repository.MemberRepository,
+	companyRepo repository.CompanyRepository,
+	vacancyRepo repository.VacancyRepository,
+	oneTimeTokenRepo repository.OneTimeTokenRepository,
+	notifications NotificationService,
+	db *gorm.DB,
+) MemberService {
+	return &memberService{
+		repo:                   repo,
+		companyRepo:            companyRepo,
+		vacancyRepo:            vacancyRepo,
+		oneTimeTokenRepository: oneTimeTokenRepo,
+		notifications:          notifications,
+		db:                     db,
+	}
+}
+
+// Invite создаёт приглашение и отправляет ссылку на почту. Повторное
+// приглашение ещё не принятого сотрудника заменяет роль и ссылку.
+func (s *memberService) Invite(
+	ctx context.Context,
+	companyId uint,
+	actor models.CompanyActor,
+	req models.InviteMemberRequest,
+) (*models.CompanyMember, error) {
+	if !actor.Role.CanManage(req.Role) {
+		return nil, constants.ErrForbidden
+	}
+
+	company, err := s.companyRepo.Get(companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	inviterName := company.Name
+	if actor.MemberID != 0 {
+		inviter, err := s.repo.GetByID(ctx, nil, actor.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		if inviter.Name != "" {
+			inviterName = inviter.Name
+		}
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	var member models.CompanyMember
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		existing, err := s.repo.GetByEmail(ctx, tx, email)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			member = models.CompanyMember{
+				CompanyID: companyId,
+				Email:     email,
+				Role:      req.Role,
+				Status:    models.MemberInvited,
+			}
+			if actor.MemberID != 0 {
+				member.InvitedByID = &actor.MemberID
+			}
+			if err := s.repo.Create(ctx, tx, &member); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case existing.CompanyID != companyId || existing.Status != models.MemberInvited:
+			return constants.ErrMemberExists
+		case !actor.Role.CanManage(existing.Role):
+			return constants.ErrForbidden
+		default:
+			member = existing
+			member.Role = req.Role
+			if err := s.repo.UpdateRole(ctx, tx, member.ID, req.Role); err != nil {
+				return err
+			}
+		}
+
+		token, err := issueOneTimeToken(
+			ctx, tx, s.oneTimeTokenRepository,
+			member.ID, models.RoleCompanyMember, models.TokenPurposeMemberInvitation, memberInvitationTTL,
+		)
+		if err != nil {
+			return err
+		}
+
+		link := appLink("/accept-invitation", token)
+		return s.notifications.MemberInvitation(ctx, tx, &member, company, inviterName, link, int(memberInvitationTTL.Hours()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (s *memberService) List(ctx context.Context, companyId uint) ([]models.CompanyMember, error) {
+	return s.repo.List(ctx, companyId)
+}
+
+// Remove исключает сотрудника из команды и гасит его приглашение.
+// Сотрудник может выйти из команды сам. Сессии сотрудника завершает
+// вызывающий.
+func (s *memberService) Remove(ctx context.Context, companyId uint, actor models.CompanyActor, memberId uint) error {
+	member, err := s.member(ctx, companyId, memberId)
+	if err != nil {
+		return err
+	}
+
+	if actor.MemberID != member.ID && !actor.Role.CanManage(member.Role) {
+		return constants.ErrForbidden
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.Delete(ctx, tx, member.ID); err != nil {
+			return err
+		}
+		return s.oneTimeTokenRepository.DeleteByUserID(
+			ctx, tx, member.ID, models.RoleCompanyMember, models.TokenPurposeMemberInvitation,
+		)
+	})
+}
+
+// AssignVacancy назначает рекрутера на вакансию компании.
+func (s *memberService) AssignVacancy(ctx context.Context, companyId uint, memberId uint, vacancyId uint) error {
+	member, err := s.member(ctx, companyId, memberId)
+	if err != nil {
+		return err
+	}
+	if member.Role != models.MemberRecruiter {
+		return constants.ErrMemberNotRecruiter
+	}
+
+	if err := s.checkVacancy(companyId, vacancyId); err != nil {
+		return err
+	}
+
+	return s.repo.AssignVacancy(ctx, vacancyId, memberId)
+}
+
+func (s *memberService) UnassignVacancy(ctx context.Context, companyId uint, memberId uint, vacancyId uint) error {
+	if _, err := s.member(ctx, companyId, memberId); err != nil {
+		return err
+	}
+
+	if err := s.checkVacancy(companyId, vacancyId); err != nil {
+		return err
+	}
+
+	return s.repo.UnassignVacancy(ctx, vacancyId, memberId)
+}
+
+// Role возвращает роль активного сотрудника компании.
+func (s *memberService) Role(companyId uint, memberId uint) (models.MemberRole, error) {
+	member, err := s.member(context.Background(), companyId, memberId)
+	if err != nil {
+		return "", err
+	}
+	if member.Status != models.MemberActive {
+		return "", constants.ErrMemberNotActive
+	}
+
+	return member.Role, nil
+}
+
+// CompanyOf возвращает компанию активного сотрудника.
+func (s *memberService) CompanyOf(ctx context.Context, memberId uint) (uint, error) {
+	member, err := s.repo.GetByID(ctx, nil, memberId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, constants.ErrMemberNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if member.Status != models.MemberActive {
+		return 0, constants.ErrMemberNotActive
+	}
+
+	return member.CompanyID, nil
+}
+
+// member находит сотрудника; сотрудник другой компании неотличим
+// от несуществующего.
+func (s *memberService) member(ctx context.Context, companyId uint, memberId uint) (models.CompanyMember, error) {
+	member, err := s.repo.GetByID(ctx, nil, memberId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && member.CompanyID != companyId {
+		return models.CompanyMember{}, constants.ErrMemberNotFound
+	}
+
+	return member, err
+}
+
+func (s *memberService) checkVacancy(companyId uint, vacancyId uint) error {
+	vacancy, err := s.vacancyRepo.GetByID(vacancyId)
+	if err != nil {
+		return err
+	}
+	if vacancy.CompanyID != companyId {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
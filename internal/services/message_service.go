@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/events"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+)
+
+// MessageService — переписка соискателя и компании по отклику.
+// Доступ есть только у сторон отклика; сотрудники компании переписываются
+// от её имени.
+type MessageService interface {
+	List(ctx context.Context, userId uint, role string, appId uint, filter models.MessageFilter) (*models.MessageThread, error)
+	Send(ctx context.Context, userId uint, role string, appId uint, req models.SendMessageRequest) (*models.Message, error)
+	MarkRead(ctx context.Context, userId uint, role string, appId uint) (int64, error)
+	Unread(ctx context.Context, userId uint, role string) (*models.MessageUnreadSummary, error)
+}
+
+type messageService struct {
+	messageRepo     repository.MessageRepository
+	applicationRepo repository.ApplicationRepository
+	access          companyAccess
+	broker          events.Broker
+	logger          *slog.Logger
+}
+
+func NewMessageService(
+	messageRepo repository.MessageRepository,
+	applicationRepo repository.ApplicationRepository,
+	memberRepo repository.MemberRepository,
+	broker events.Broker,
+	logger *slog.Logger,
+) MessageService {
+	return &messageService{
+		messageRepo:     messageRepo,
+		applicationRepo: applicationRepo,
+		access:          companyAccess{members: memberRepo},
+		broker:          broker,
+		logger:          logger,
+	}
+}
+
+func (s *messageService) List(
+	ctx context.Context,
+	userId uint,
+	role string,
+	appId uint,
+	filter models.MessageFilter,
+) (*models.MessageThread, error) {
+	app, err := s.thread(ctx, userId, role, appId, accessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.messageRepo.List(ctx, appId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	_, side := applicationSide(app, userId, role)
+	unread, err := s.messageRepo.CountUnread(ctx, appId, side)
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []models.Message{}
+	}
+	return &models.MessageThread{Items: items, Unread: unread}, nil
+}
+
+func (s *messageService) Send(
+	ctx context.Context,
+	userId uint,
+	role string,
+	appId uint,
+	req models.SendMessageRequest,
+) (*models.Message, error) {
+	app, err := s.thread(ctx, userId, role, appId, accessWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	senderId, senderRole := applicationSide(app, userId, role)
+	message := &models.Message{
+		ApplicationID: appId,
+		SenderID:      senderId,
+		SenderRole:    senderRole,
+		Body:          req.Body,
+		Attachments:   make([]models.MessageAttachment, 0, len(req.Attachments)),
+	}
+	for _, attachment := range req.Attachments {
+		message.Attachments = append(message.Attachments, models.MessageAttachment{
+			Name:        attachment.Name,
+			URL:         attachment.URL,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+		})
+	}
+
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		s.logger.Error("не удалось сохранить сообщение",
+			slog.Uint64("application_id", uint64(appId)),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	s.broker.Publish(ctx,
+		events.Event{Type: events.TypeMessageCreated, Data: message},
+		applicationRecipients(app.Resume.ApplicantID, app.Vacancy.CompanyID)...,
+	)
+
+	return message, nil
+}
+
+// MarkRead отмечает прочитанными все сообщения другой стороны в переписке.
+func (s *messageService) MarkRead(ctx context.Context, userId uint, role string, appId uint) (int64, error) {
+	app, err := s.thread(ctx, userId, role, appId, accessRead)
+	if err != nil {
+		return 0, err
+	}
+
+	_, side := applicationSide(app, userId, role)
+	readAt := time.Now()
+	updated, err := s.messageRepo.MarkRead(ctx, appId, side, readAt)
+	if err != nil {
+		return 0, err
+	}
+
+	if updated > 0 {
+		payload := events.MessagesReadPayload{
+			ApplicationID: appId,
+			ReaderRole:    side,
+			ReadAt:        readAt,
+			Count:         updated,
+		}
+		s.broker.Publish(ctx,
+			events.Event{Type: events.TypeMessagesRead, Data: payload},
+			applicationRecipients(app.Resume.ApplicantID, app.Vacancy.CompanyID)...,
+		)
+	}
+
+	return updated, nil
+}
+
+func (s *messageService) Unread(ctx context.Context, userId uint, role string) (*models.MessageUnreadSummary, error) {
+	if role == models.RoleCompanyMember {
+		companyId, err := s.access.companyOf(ctx, userId, role)
+		if err != nil {
+			return nil, err
+		}
+		userId, role = companyId, models.RoleCompany
+	}
+
+	applications, err := s.messageRepo.UnreadByApplication(ctx, userId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.MessageUnreadSummary{Applications: []models.MessageUnread{}}
+	for _, item := range applications {
+		summary.Total += item.Unread
+		summary.Applications = append(summary.Applications, item)
+	}
+
+	return summary, nil
+}
+
+// thread проверяет, что субъект — сторона отклика, и возвращает отклик.
+func (s *messageService) thread(
+	ctx context.Context,
+	userId uint,
+	role string,
+	appId uint,
+	mode accessMode,
+) (*models.Application, error) {
+	app, err := s.applicationRepo.GetByID(appId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.access.checkApplication(ctx, app, userId, role, mode); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
@@ -0,0 +1,626 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/ical"
+	"github.com/AliUmarov/team-find-me-job/internal/mail"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NotificationService сообщает пользователям о событиях: уведомлением
+// в приложении и, если пользователь не отключил, письмом через outbox.
+// Методы событий принимают транзакцию изменения, о котором сообщают.
+type NotificationService interface {
+	EmailVerification(ctx context.Context, tx *gorm.DB, applicant *models.Applicant, link string, expiresHours int) error
+	PasswordReset(ctx context.Context, tx *gorm.DB, applicant *models.Applicant, link string, expiresHours int) error
+	MemberInvitation(
+		ctx context.Context,
+		tx *gorm.DB,
+		member *models.CompanyMember,
+		company *models.Company,
+		inviterName string,
+		link string,
+		expiresHours int,
+	) error
+	ApplicationReceived(ctx context.Context, tx *gorm.DB, application *models.Application) error
+	ApplicationStatusChanged(ctx context.Context, tx *gorm.DB, application *models.Application, change *models.ApplicationStatusChange) error
+	ResumeImproved(ctx context.Context, tx *gorm.DB, resume *models.Resume) error
+	InterviewChanged(ctx context.Context, tx *gorm.DB, notificationType string, interview *models.Interview) error
+	SavedSearchDigest(ctx context.Context, tx *gorm.DB, search *models.SavedSearch, result *models.VacancySearchResult) error
+
+	List(ctx context.Context, userId uint, role string, filter models.NotificationFilter) (*models.NotificationList, error)
+	UnreadCount(ctx context.Context, userId uint, role string) (int64, error)
+	MarkRead(ctx context.Context, userId uint, role string, id uint) error
+	MarkAllRead(ctx context.Context, userId uint, role string) (int64, error)
+	Preferences(ctx context.Context, userId uint, role string) ([]models.NotificationPreferenceItem, error)
+	UpdatePreferences(
+		ctx context.Context,
+		userId uint,
+		role string,
+		req models.UpdateNotificationPreferencesRequest,
+	) ([]models.NotificationPreferenceItem, error)
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	outboxRepo       repository.OutboxRepository
+	applicantRepo    repository.ApplicantRepository
+	companyRepo      repository.CompanyRepository
+	vacancyRepo      repository.VacancyRepository
+	resumeRepo       repository.ResumeRepository
+	memberRepo       repository.MemberRepository
+	logger           *slog.Logger
+}
+
+func NewNotificationService(
+	notificationRepo repository.NotificationRepository,
+	outboxRepo repository.OutboxRepository,
+	applicantRepo repository.ApplicantRepository,
+	companyRepo repository.CompanyRepository,
+	vacancyRepo repository.VacancyRepository,
+	resumeRepo repository.ResumeRepository,
+	memberRepo repository.MemberRepository,
+	logger *slog.Logger,
+) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
+		applicantRepo:    applicantRepo,
+		companyRepo:      companyRepo,
+		vacancyRepo:      vacancyRepo,
+		resumeRepo:       resumeRepo,
+		memberRepo:       memberRepo,
+		logger:           logger,
+	}
+}
+
+// recipient — получатель уведомления.
+type recipient struct {
+	UserID uint
+	Role   string
+	Name   string
+	Email  string
+	Locale string
+}
+
+// Письма о подтверждении почты и сбросе пароля отправляются всегда
+// и в центр уведомлений не попадают.
+
+func (s *notificationService) EmailVerification(
+	ctx context.Context,
+	tx *gorm.DB,
+	applicant *models.Applicant,
+	link string,
+	expiresHours int,
+) error {
+	data := mail.LinkData{Name: applicant.FullName, Link: link, ExpiresHours: expiresHours}
+	return s.sendEmail(ctx, tx, applicant.Email, applicant.Locale, mail.TemplateVerifyEmail, data)
+}
+
+func (s *notificationService) PasswordReset(
+	ctx context.Context,
+	tx *gorm.DB,
+	applicant *models.Applicant,
+	link string,
+	expiresHours int,
+) error {
+	data := mail.LinkData{Name: applicant.FullName, Link: link, ExpiresHours: expiresHours}
+	return s.sendEmail(ctx, tx, applicant.Email, applicant.Locale, mail.TemplateResetPassword, data)
+}
+
+// MemberInvitation отправляет приглашение в команду на языке компании.
+func (s *notificationService) MemberInvitation(
+	ctx context.Context,
+	tx *gorm.DB,
+	member *models.CompanyMember,
+	company *models.Company,
+	inviterName string,
+	link string,
+	expiresHours int,
+) error {
+	data := mail.InvitationData{
+		CompanyName:  company.Name,
+		InviterName:  inviterName,
+		Role:         string(member.Role),
+		Link:         link,
+		ExpiresHours: expiresHours,
+	}
+	return s.sendEmail(ctx, tx, member.Email, company.Locale, mail.TemplateMemberInvitation, data)
+}
+
+// ApplicationReceived сообщает компании и её сотрудникам, которые ведут
+// вакансию, о новом отклике.
+func (s *notificationService) ApplicationReceived(ctx context.Context, tx *gorm.DB, application *models.Application) error {
+	vacancy, company, applicant, err := s.applicationParties(application)
+	if err != nil {
+		return err
+	}
+
+	team, err := s.companyTeam(ctx, tx, company, vacancy.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, to := range team {
+		data := mail.ApplicationData{
+			RecipientName: to.Name,
+			ApplicantName: applicant.Name,
+			CompanyName:   company.Name,
+			VacancyTitle:  vacancy.Title,
+			Status:        string(application.Status),
+			MatchScore:    application.MatchScore,
+		}
+		notification := applicationNotification(models.NotificationApplicationReceived, application)
+		if err := s.notify(ctx, tx, to, notification, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplicationStatusChanged сообщает о смене статуса отклика другой
+// стороне: соискателю — о решении компании, компании и её сотрудникам,
+// которые ведут вакансию, — об отзыве отклика.
+func (s *notificationService) ApplicationStatusChanged(
+	ctx context.Context,
+	tx *gorm.DB,
+	application *models.Application,
+	change *models.ApplicationStatusChange,
+) error {
+	vacancy, company, applicant, err := s.applicationParties(application)
+	if err != nil {
+		return err
+	}
+
+	data := mail.ApplicationData{
+		ApplicantName: applicant.Name,
+		CompanyName:   company.Name,
+		VacancyTitle:  vacancy.Title,
+		Status:        string(change.ToStatus),
+		Comment:       change.Comment,
+	}
+
+	if change.ChangedByRole == models.RoleApplicant {
+		if change.ToStatus != models.StatusWithdrawn {
+			return nil
+		}
+		team, err := s.companyTeam(ctx, tx, company, vacancy.ID)
+		if err != nil {
+			return err
+		}
+		for _, to := range team {
+			data.RecipientName = to.Name
+			notification := applicationNotification(models.NotificationApplicationWithdrawn, application)
+			if err := s.notify(ctx, tx, to, notification, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data.RecipientName = applicant.Name
+	notification := applicationNotification(models.NotificationApplicationStatusChanged, application)
+	return s.notify(ctx, tx, applicant, notification, data)
+}
+
+// ResumeImproved сообщает соискателю, что предложения модели по резюме готовы.
+func (s *notificationService) ResumeImproved(ctx context.Context, tx *gorm.DB, resume *models.Resume) error {
+	applicant, err := s.applicant(resume.ApplicantID)
+	if err != nil {
+		return err
+	}
+
+	data := mail.ResumeData{
+		RecipientName: applicant.Name,
+		Position:      resume.Position,
+		Score:         resume.AIScore,
+	}
+	notification := &models.Notification{Type: models.NotificationResumeImproved, ResumeID: &resume.ID}
+	return s.notify(ctx, tx, applicant, notification, data)
+}
+
+// InterviewChanged сообщает обеим сторонам о назначении, переносе или
+// отмене собеседования; со стороны компании — и сотрудникам, которые
+// ведут вакансию. К письмам прикладывается файл .ics.
+func (s *notificationService) InterviewChanged(
+	ctx context.Context,
+	tx *gorm.DB,
+	notificationType string,
+	interview *models.Interview,
+) error {
+	vacancy, err := s.vacancyRepo.GetByID(interview.VacancyID)
+	if err != nil {
+		return err
+	}
+
+	company, err := s.companyRepo.Get(interview.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	applicant, err := s.applicant(interview.ApplicantID)
+	if err != nil {
+		return err
+	}
+
+	event := interviewEvent(interview, vacancy, company, ical.Person{Name: applicant.Name, Email: applicant.Email})
+	invite := mail.Attachment{
+		Filename:    "interview.ics",
+		ContentType: event.ContentType(),
+		Data:        event.Marshal(),
+	}
+
+	team, err := s.companyTeam(ctx, tx, companyRecipient(company), vacancy.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, to := range append([]recipient{applicant}, team...) {
+		data := mail.InterviewData{
+			RecipientName: to.Name,
+			ApplicantName: applicant.Name,
+			CompanyName:   company.Name,
+			VacancyTitle:  vacancy.Title,
+			StartsAt:      interview.StartsAt,
+			EndsAt:        interview.EndsAt,
+			Location:      interview.Location,
+			Reason:        interview.CancelReason,
+		}
+		notification := &models.Notification{
+			Type:          notificationType,
+			ApplicationID: &interview.ApplicationID,
+			VacancyID:     &interview.VacancyID,
+		}
+		if err := s.notify(ctx, tx, to, notification, data, invite); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SavedSearchDigest присылает соискателю подборку новых вакансий
+// по сохранённому поиску.
+func (s *notificationService) SavedSearchDigest(
+	ctx context.Context,
+	tx *gorm.DB,
+	search *models.SavedSearch,
+	result *models.VacancySearchResult,
+) error {
+	applicant, err := s.applicant(search.ApplicantID)
+	if err != nil {
+		return err
+	}
+
+	data := mail.DigestData{
+		RecipientName: applicant.Name,
+		SearchName:    search.Name,
+		Vacancies:     make([]mail.DigestVacancy, 0, len(result.Items)),
+		Total:         result.Total,
+		Link:          appURL(fmt.Sprintf("/saved-searches/%d", search.ID)),
+	}
+
+	companies := map[uint]string{}
+	for _, vacancy := range result.Items {
+		name, ok := companies[vacancy.CompanyID]
+		if !ok {
+			company, err := s.companyRepo.Get(vacancy.CompanyID)
+			if err != nil {
+				return err
+			}
+			name = company.Name
+			companies[vacancy.CompanyID] = name
+		}
+
+		data.Vacancies = append(data.Vacancies, mail.DigestVacancy{
+			Title:       vacancy.Title,
+			CompanyName: name,
+			City:        vacancy.City,
+			Link:        appURL(fmt.Sprintf("/vacancies/%d", vacancy.ID)),
+		})
+	}
+
+	notification := &models.Notification{Type: models.NotificationSavedSearchDigest, SavedSearchID: &search.ID}
+	return s.notify(ctx, tx, applicant, notification, data)
+}
+
+func applicationNotification(notificationType string, application *models.Application) *models.Notification {
+	return &models.Notification{
+		Type:          notificationType,
+		ApplicationID: &application.ID,
+		VacancyID:     &application.VacancyID,
+		ResumeID:      &application.ResumeID,
+	}
+}
+
+// applicationParties загружает вакансию и обе стороны отклика.
+func (s *notificationService) applicationParties(application *models.Application) (
+	*models.Vacancy,
+	recipient,
+	recipient,
+	error,
+) {
+	vacancy, err := s.vacancyRepo.GetByID(application.VacancyID)
+	if err != nil {
+		return nil, recipient{}, recipient{}, err
+	}
+
+	company, err := s.companyRepo.Get(vacancy.CompanyID)
+	if err != nil {
+		return nil, recipient{}, recipient{}, err
+	}
+
+	resume, err := s.resumeRepo.GetByID(application.ResumeID)
+	if err != nil {
+		return nil, recipient{}, recipient{}, err
+	}
+
+	applicant, err := s.applicant(resume.ApplicantID)
+	if err != nil {
+		return nil, recipient{}, recipient{}, err
+	}
+
+	return vacancy, companyRecipient(company), applicant, nil
+}
+
+func companyRecipient(company *models.Company) recipient {
+	return recipient{
+		UserID: company.ID,
+		Role:   models.RoleCompany,
+		Name:   company.Name,
+		Email:  company.Email,
+		Locale: company.Locale,
+	}
+}
+
+// companyTeam возвращает получателей со стороны компании: саму компанию
+// и сотрудников, которые ведут вакансию. Письма сотрудникам приходят
+// на языке компании.
+func (s *notificationService) companyTeam(
+	ctx context.Context,
+	tx *gorm.DB,
+	company recipient,
+	vacancyId uint,
+) ([]recipient, error) {
+	members, err := s.memberRepo.ListVacancyTeam(ctx, tx, company.UserID, vacancyId)
+	if err != nil {
+		return nil, err
+	}
+
+	team := make([]recipient, 0, len(members)+1)
+	team = append(team, company)
+	for _, member := range members {
+		team = append(team, recipient{
+			UserID: member.ID,
+			Role:   models.RoleCompanyMember,
+			Name:   member.Name,
+			Email:  member.Email,
+			Locale: company.Locale,
+		})
+	}
+	return team, nil
+}
+
+func (s *notificationService) applicant(id uint) (recipient, error) {
+	applicant, err := s.applicantRepo.GetByID(id)
+	if err != nil {
+		return recipient{}, err
+	}
+
+	return recipient{
+		UserID: id,
+		Role:   models.RoleApplicant,
+		Name:   applicant.FullName,
+		Email:  applicant.Email,
+		Locale: applicant.Locale,
+	}, nil
+}
+
+// notify сохраняет уведомление в приложении и ставит письмо в очередь,
+// если получатель не отключил почту для этого типа событий.
+func (s *notificationService) notify(
+	ctx context.Context,
+	tx *gorm.DB,
+	to recipient,
+	notification *models.Notification,
+	data any,
+	attachments ...mail.Attachment,
+) error {
+	msg, err := mail.Render(notification.Type, mail.ParseLanguage(to.Locale), data)
+	if err != nil {
+		return err
+	}
+
+	notification.UserID = to.UserID
+	notification.Role = to.Role
+	notification.Title = msg.Subject
+	notification.Body = msg.Summary
+	if err := s.notificationRepo.Create(ctx, tx, notification); err != nil {
+		return err
+	}
+
+	// Компании, созданные до регистрации по почте, писем не получают.
+	if to.Email == "" {
+		return nil
+	}
+
+	byEmail, err := s.emailEnabled(ctx, tx, to, notification.Type)
+	if err != nil {
+		return err
+	}
+	if !byEmail {
+		return nil
+	}
+
+	msg.To = to.Email
+	msg.Attachments = attachments
+	return s.enqueue(ctx, tx, notification.Type, msg)
+}
+
+func (s *notificationService) emailEnabled(ctx context.Context, tx *gorm.DB, to recipient, notificationType string) (bool, error) {
+	preferences, err := s.effectivePreferences(ctx, tx, to.UserID, to.Role)
+	if err != nil {
+		return false, err
+	}
+
+	for _, preference := range preferences {
+		if preference.Type == notificationType {
+			return preference.Email, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *notificationService) sendEmail(
+	ctx context.Context,
+	tx *gorm.DB,
+	to string,
+	locale string,
+	template string,
+	data any,
+) error {
+	msg, err := mail.Render(template, mail.ParseLanguage(locale), data)
+	if err != nil {
+		return err
+	}
+
+	msg.To = to
+	return s.enqueue(ctx, tx, template, msg)
+}
+
+func (s *notificationService) enqueue(ctx context.Context, tx *gorm.DB, template string, msg mail.Message) error {
+	email := &models.OutboxEmail{
+		Template: template,
+		To:       msg.To,
+		Subject:  msg.Subject,
+		HTML:     msg.HTML,
+		Text:     msg.Text,
+	}
+	if len(msg.Attachments) > 0 {
+		attachments, err := json.Marshal(msg.Attachments)
+		if err != nil {
+			return err
+		}
+		email.Attachments = attachments
+	}
+	if err := s.outboxRepo.Enqueue(ctx, tx, email); err != nil {
+		return err
+	}
+
+	s.logger.Debug("письмо поставлено в очередь",
+		slog.Uint64("email_id", uint64(email.ID)),
+		slog.String("template", template),
+	)
+	return nil
+}
+
+func (s *notificationService) List(
+	ctx context.Context,
+	userId uint,
+	role string,
+	filter models.NotificationFilter,
+) (*models.NotificationList, error) {
+	items, err := s.notificationRepo.List(ctx, userId, role, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []models.Notification{}
+	}
+	return &models.NotificationList{Items: items, Unread: unread}, nil
+}
+
+func (s *notificationService) UnreadCount(ctx context.Context, userId uint, role string) (int64, error) {
+	return s.notificationRepo.CountUnread(ctx, userId, role)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userId uint, role string, id uint) error {
+	found, err := s.notificationRepo.MarkRead(ctx, userId, role, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return constants.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userId uint, role string) (int64, error) {
+	return s.notificationRepo.MarkAllRead(ctx, userId, role)
+}
+
+func (s *notificationService) Preferences(ctx context.Context, userId uint, role string) ([]models.NotificationPreferenceItem, error) {
+	return s.effectivePreferences(ctx, nil, userId, role)
+}
+
+func (s *notificationService) UpdatePreferences(
+	ctx context.Context,
+	userId uint,
+	role string,
+	req models.UpdateNotificationPreferencesRequest,
+) ([]models.NotificationPreferenceItem, error) {
+	defaults := models.DefaultNotificationPreferences(role)
+
+	preferences := make([]models.NotificationPreference, 0, len(req.Preferences))
+	for _, item := range req.Preferences {
+		known := slices.ContainsFunc(defaults, func(d models.NotificationPreferenceItem) bool {
+			return d.Type == item.Type
+		})
+		if !known {
+			return nil, constants.ErrUnknownNotificationType
+		}
+
+		preferences = append(preferences, models.NotificationPreference{
+			UserID: userId,
+			Role:   role,
+			Type:   item.Type,
+			Email:  item.Email,
+		})
+	}
+
+	if err := s.notificationRepo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return s.effectivePreferences(ctx, nil, userId, role)
+}
+
+// effectivePreferences накладывает сохранённые настройки на значения по умолчанию.
+func (s *notificationService) effectivePreferences(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId uint,
+	role string,
+) ([]models.NotificationPreferenceItem, error) {
+	saved, err := s.notificationRepo.Preferences(ctx, tx, userId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := models.DefaultNotificationPreferences(role)
+	for i := range preferences {
+		for _, preference := range saved {
+			if preference.Type == preferences[i].Type {
+				preferences[i].Email = preference.Email
+			}
+		}
+	}
+
+	return preferences, nil
+}
@@ -1,13 +1,20 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/AliUmarov/team-find-me-job/internal/ai"
 	"github.com/AliUmarov/team-find-me-job/internal/constants"
-	"github.com/AliUmarov/team-find-me-job/internal/gigachat"
+	"github.com/AliUmarov/team-find-me-job/internal/export"
+	"github.com/AliUmarov/team-find-me-job/internal/extract"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
 )
 
 type ResumeService interface {
@@ -16,22 +23,44 @@ type ResumeService interface {
 	GetByID(id uint) (*models.Resume, error)
 	Update(id uint, req models.ResumeUpdateRequest) (*models.Resume, error)
 	Delete(id uint) error
-	ImproveResume(id uint) (*models.Resume, error)
+	ImproveResume(ownerId uint, id uint) (*models.AIJob, error)
+	ProcessImprove(ctx context.Context, id uint) (*models.Resume, error)
+	Versions(id uint) ([]models.ResumeVersion, error)
+	Version(id uint, number int) (*models.ResumeVersion, error)
+	DiffVersions(id uint, from, to int) (*models.ResumeVersionDiff, error)
+	RestoreVersion(id uint, number int) (*models.Resume, error)
+	AcceptAISuggestion(id uint) (*models.Resume, error)
+	Export(id uint, req models.ResumeExportRequest) (*export.File, error)
+	ImportDraft(ctx context.Context, filename string, data []byte) (*models.ResumeCreateRequest, error)
 }
 
 type resumeService struct {
 	repo          repository.ResumeRepository
 	applicantRepo repository.ApplicantRepository
+	jobRepo       repository.AIJobRepository
 	logger        *slog.Logger
-	client        *gigachat.Client
+	llm           ai.LLMProvider
+	renderer      *export.Renderer
+	notifications NotificationService
 }
 
-func NewResumeService(repo repository.ResumeRepository, applicantRepo repository.ApplicantRepository, logger *slog.Logger, client *gigachat.Client) ResumeService {
+func NewResumeService(
+	repo repository.ResumeRepository,
+	applicantRepo repository.ApplicantRepository,
+	jobRepo repository.AIJobRepository,
+	logger *slog.Logger,
+	llm ai.LLMProvider,
+	renderer *export.Renderer,
+	notifications NotificationService,
+) ResumeService {
 	return &resumeService{
 		repo:          repo,
 		applicantRepo: applicantRepo,
+		jobRepo:       jobRepo,
 		logger:        logger,
-		client:        client,
+		llm:           llm,
+		renderer:      renderer,
+		notifications: notifications,
 	}
 }
 
@@ -50,6 +79,10 @@ func (s *resumeService) Create(id uint, req models.ResumeCreateRequest) (*models
 		Salary:      req.Salary,
 		ApplicantID: applicant.ID,
 	}
+	resume.SetWorkExperience(req.WorkExperience)
+	resume.SetEducation(req.Education)
+	resume.SetSkillList(req.SkillList)
+	resume.SetLanguages(req.Languages)
 
 	if err := s.repo.Create(&resume); err != nil {
 		s.logger.Error("ошибка при добавлении резюме",
@@ -94,6 +127,7 @@ func (s *resumeService) Update(id uint, req models.ResumeUpdateRequest) (*models
 		)
 		return nil, err
 	}
+	before := models.NewResumeVersion(resume, models.ResumeVersionManual)
 
 	if req.Position != nil {
 		resume.Position = *req.Position
@@ -119,7 +153,28 @@ func (s *resumeService) Update(id uint, req models.ResumeUpdateRequest) (*models
 		resume.Salary = *req.Salary
 	}
 
-	if err := s.repo.Update(id, resume); err != nil {
+	if req.WorkExperience != nil {
+		resume.SetWorkExperience(*req.WorkExperience)
+	}
+
+	if req.Education != nil {
+		resume.SetEducation(*req.Education)
+	}
+
+	if req.SkillList != nil {
+		resume.SetSkillList(*req.SkillList)
+	}
+
+	if req.Languages != nil {
+		resume.SetLanguages(*req.Languages)
+	}
+
+	version := models.NewResumeVersion(resume, models.ResumeVersionManual)
+	if len(before.Diff(version).Changes) == 0 {
+		return resume, nil
+	}
+
+	if err := s.repo.SaveWithVersion(resume, version, nil); err != nil {
 		s.logger.Error("не удалось сохранить изменения",
 			slog.Uint64("resume_id", uint64(id)),
 			slog.Any("error", err),
@@ -142,7 +197,34 @@ func (s *resumeService) Delete(id uint) error {
 	return nil
 }
 
-func (s *resumeService) ImproveResume(id uint) (*models.Resume, error) {
+// ImproveResume ставит улучшение резюме в очередь ИИ-задач.
+// Результат запишет воркер через ProcessImprove.
+func (s *resumeService) ImproveResume(ownerId uint, id uint) (*models.AIJob, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	job := models.AIJob{
+		Type:      models.AIJobTypeImproveResume,
+		Status:    models.AIJobQueued,
+		ResumeID:  id,
+		OwnerID:   ownerId,
+		OwnerRole: models.RoleApplicant,
+		RunAt:     time.Now(),
+	}
+
+	if err := s.jobRepo.Create(&job); err != nil {
+		s.logger.Error("не удалось поставить улучшение резюме в очередь",
+			slog.Uint64("resume_id", uint64(id)),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (s *resumeService) ProcessImprove(ctx context.Context, id uint) (*models.Resume, error) {
 	resume, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -158,17 +240,182 @@ func (s *resumeService) ImproveResume(id uint) (*models.Resume, error) {
 		Salary: %d
 	`, resume.Position, resume.Summary, resume.Skills, resume.Experience, resume.Portfolio, resume.Salary)
 
-	improved, score, err := gigachat.ImproveResume(fullText, s.client)
+	result, err := ai.ImproveResume(ctx, s.llm, fullText)
+	if err != nil {
+		return nil, err
+	}
+
+	// Пока модель отвечала, соискатель мог изменить резюме: предложение
+	// строится поверх перечитанного под блокировкой состояния.
+	suggest := func(current *models.Resume) *models.ResumeVersion {
+		current.AIImproved = result.Improved
+		current.AIScore = result.Score
+
+		// Основные поля резюме не меняются: предложение модели попадает
+		// только в версию и применяется через AcceptAISuggestion.
+		version := models.NewResumeVersion(current, models.ResumeVersionAI)
+		version.Position = suggested(result.Fields.Position, current.Position)
+		version.Summary = suggested(result.Fields.Summary, current.Summary)
+		version.Skills = suggested(result.Fields.Skills, current.Skills)
+		version.Experience = suggested(result.Fields.Experience, current.Experience)
+		version.Portfolio = suggested(result.Fields.Portfolio, current.Portfolio)
+		return version
+	}
+	notify := func(tx *gorm.DB, resume *models.Resume) error {
+		return s.notifications.ResumeImproved(ctx, tx, resume)
+	}
+
+	return s.repo.SaveAISuggestion(id, suggest, notify)
+}
+
+// suggested возвращает предложенное моделью значение поля,
+// а если модель его не заполнила — текущее.
+func suggested(value, current string) string {
+	if strings.TrimSpace(value) == "" {
+		return current
+	}
+	return value
+}
+
+func (s *resumeService) Versions(id uint) ([]models.ResumeVersion, error) {
+	return s.repo.Versions(id)
+}
+
+func (s *resumeService) Version(id uint, number int) (*models.ResumeVersion, error) {
+	return s.repo.Version(id, number)
+}
+
+func (s *resumeService) DiffVersions(id uint, from, to int) (*models.ResumeVersionDiff, error) {
+	fromVersion, err := s.repo.Version(id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := s.repo.Version(id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := fromVersion.Diff(toVersion)
+	return &diff, nil
+}
+
+// RestoreVersion возвращает основные поля резюме к состоянию версии number.
+func (s *resumeService) RestoreVersion(id uint, number int) (*models.Resume, error) {
+	version, err := s.repo.Version(id, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyVersion(id, version, models.ResumeVersionRestored)
+}
+
+// AcceptAISuggestion переносит в основные поля последнее предложение модели.
+func (s *resumeService) AcceptAISuggestion(id uint) (*models.Resume, error) {
+	version, err := s.repo.LatestVersion(id, models.ResumeVersionAI)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrNoAISuggestion
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	resume.AIImproved = improved
-	resume.AIScore = score
+	return s.applyVersion(id, version, models.ResumeVersionAIAccepted)
+}
 
-	if err := s.repo.Save(resume); err != nil {
+func (s *resumeService) applyVersion(id uint, version *models.ResumeVersion, source models.ResumeVersionSource) (*models.Resume, error) {
+	resume, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	version.ApplyTo(resume)
+
+	snapshot := models.NewResumeVersion(resume, source)
+	snapshot.RestoredFrom = &version.Number
+
+	if err := s.repo.SaveWithVersion(resume, snapshot, nil); err != nil {
+		s.logger.Error("не удалось применить версию резюме",
+			slog.Uint64("resume_id", uint64(id)),
+			slog.Int("version", version.Number),
+			slog.Any("error", err),
+		)
 		return nil, err
 	}
 
 	return resume, nil
 }
+
+func (s *resumeService) Export(id uint, req models.ResumeExportRequest) (*export.File, error) {
+	resume, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Variant == "ai" {
+		version, err := s.repo.LatestVersion(id, models.ResumeVersionAI)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrNoAISuggestion
+		}
+		if err != nil {
+			return nil, err
+		}
+		version.ApplyTo(resume)
+	}
+
+	applicant, err := s.applicantRepo.GetByID(resume.ApplicantID)
+	if err != nil {
+		return nil, fmt.Errorf("error: %v, details: %v", err, constants.ERR_CAN_NOT_GET_APPLICANT)
+	}
+
+	format := export.Format(req.Format)
+	if format == "" {
+		format = export.FormatPDF
+	}
+	template := req.Template
+	if template == "" {
+		template = export.TemplateClassic
+	}
+
+	doc := export.NewDocument(resume)
+	doc.FullName = applicant.FullName
+	doc.Email = applicant.Email
+	doc.Phone = applicant.Phone
+
+	file, err := s.renderer.Render(doc, format, template)
+	if err != nil {
+		s.logger.Error("не удалось выгрузить резюме",
+			slog.Uint64("resume_id", uint64(id)),
+			slog.String("format", string(format)),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// ImportDraft достаёт текст из загруженного PDF/DOCX и просит модель
+// разложить его по полям резюме. Черновик не сохраняется: соискатель
+// правит его и отправляет в Create.
+func (s *resumeService) ImportDraft(ctx context.Context, filename string, data []byte) (*models.ResumeCreateRequest, error) {
+	text, err := extract.Text(filename, data)
+	if err != nil {
+		s.logger.Warn("не удалось прочитать загруженное резюме",
+			slog.String("filename", filename),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	draft, err := ai.ExtractResume(ctx, s.llm, text)
+	if err != nil {
+		s.logger.Error("не удалось разобрать резюме моделью",
+			slog.String("filename", filename),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	return draft, nil
+}
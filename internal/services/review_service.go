@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+)
+
+type ReviewService interface {
+	Create(ctx context.Context, applicantId uint, companyId uint, req models.CreateReviewRequest) (*models.CompanyReview, error)
+	List(ctx context.Context, companyId uint, filter models.ReviewFilter) (*models.ReviewList, error)
+	Reply(ctx context.Context, companyId uint, reviewId uint, req models.ReplyReviewRequest) (*models.CompanyReview, error)
+	Delete(ctx context.Context, applicantId uint, reviewId uint) error
+	ModerationQueue(ctx context.Context, filter models.ReviewFilter) (*models.ReviewList, error)
+	Moderate(ctx context.Context, reviewId uint, req models.ModerateReviewRequest) (*models.CompanyReview, error)
+}
+
+type reviewService struct {
+	repo repository.ReviewRepository
+}
+
+func NewReviewService(repo repository.ReviewRepository) ReviewService {
+	return &reviewService{repo: repo}
+}
+
+// Create сохраняет отзыв на модерацию: в рейтинг и публичный список
+// он попадёт после публикации.
+func (s *reviewService) Create(
+	ctx context.Context,
+	applicantId uint,
+	companyId uint,
+	req models.CreateReviewRequest,
+) (*models.CompanyReview, error) {
+	review := &models.CompanyReview{
+		CompanyID:       companyId,
+		ApplicantID:     applicantId,
+		Rating:          req.Rating,
+		SalaryScore:     req.SalaryScore,
+		CultureScore:    req.CultureScore,
+		GrowthScore:     req.GrowthScore,
+		ManagementScore: req.ManagementScore,
+		Title:           strings.TrimSpace(req.Title),
+		Pros:            strings.TrimSpace(req.Pros),
+		Cons:            strings.TrimSpace(req.Cons),
+		Text:            strings.TrimSpace(req.Text),
+		Status:          models.ReviewPending,
+	}
+	if err := s.repo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// List возвращает опубликованные отзывы о компании.
+func (s *reviewService) List(ctx context.Context, companyId uint, filter models.ReviewFilter) (*models.ReviewList, error) {
+	filter.Status = models.ReviewPublished
+	return s.repo.List(ctx, &companyId, filter)
+}
+
+func (s *reviewService) Reply(
+	ctx context.Context,
+	companyId uint,
+	reviewId uint,
+	req models.ReplyReviewRequest,
+) (*models.CompanyReview, error) {
+	return s.repo.Reply(ctx, companyId, reviewId, strings.TrimSpace(req.Reply))
+}
+
+func (s *reviewService) Delete(ctx context.Context, applicantId uint, reviewId uint) error {
+	return s.repo.Delete(ctx, applicantId, reviewId)
+}
+
+// ModerationQueue возвращает отзывы всех компаний для модерации,
+// по умолчанию — ожидающие проверки, старые первыми.
+func (s *reviewService) ModerationQueue(ctx context.Context, filter models.ReviewFilter) (*models.ReviewList, error) {
+	if filter.Status == "" {
+		filter.Status = models.ReviewPending
+	}
+	if filter.Order == "" {
+		filter.Order = "asc"
+	}
+	return s.repo.List(ctx, nil, filter)
+}
+
+func (s *reviewService) Moderate(
+	ctx context.Context,
+	reviewId uint,
+	req models.ModerateReviewRequest,
+) (*models.CompanyReview, error) {
+	return s.repo.Moderate(ctx, reviewId, req.Status, strings.TrimSpace(req.Comment))
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"gorm.io/gorm"
+)
+
+// digestVacancyLimit — сколько вакансий перечисляется в подборке;
+// об остальных сообщает общее число найденных.
+const digestVacancyLimit = 10
+
+// SavedService — закладки соискателя на вакансии и сохранённые поиски
+// с периодическими подборками новых вакансий.
+type SavedService interface {
+	SaveVacancy(ctx context.Context, applicantId uint, vacancyId uint) error
+	UnsaveVacancy(ctx context.Context, applicantId uint, vacancyId uint) error
+	SavedVacancies(ctx context.Context, applicantId uint, filter models.SavedVacancyFilter) (*models.SavedVacancyList, error)
+
+	CreateSearch(ctx context.Context, applicantId uint, req models.SavedSearchRequest) (*models.SavedSearch, error)
+	Searches(ctx context.Context, applicantId uint) ([]models.SavedSearch, error)
+	UpdateSearch(ctx context.Context, applicantId uint, id uint, req models.SavedSearchRequest) (*models.SavedSearch, error)
+	DeleteSearch(ctx context.Context, applicantId uint, id uint) error
+
+	SendNextDigest(ctx context.Context) (bool, error)
+}
+
+type savedService struct {
+	savedVacancyRepo repository.SavedVacancyRepository
+	savedSearchRepo  repository.SavedSearchRepository
+	vacancyRepo      repository.VacancyRepository
+	notifications    NotificationService
+	db               *gorm.DB
+}
+
+func NewSavedService(
+	savedVacancyRepo repository.SavedVacancyRepository,
+	savedSearchRepo repository.SavedSearchRepository,
+	vacancyRepo repository.VacancyRepository,
+	notifications NotificationService,
+	db *gorm.DB,
+) SavedService {
+	return &savedService{
+		savedVacancyRepo: savedVacancyRepo,
+		savedSearchRepo:  savedSearchRepo,
+		vacancyRepo:      vacancyRepo,
+		notifications:    notifications,
+		db:               db,
+	}
+}
+
+// SaveVacancy добавляет в закладки опубликованную вакансию.
+func (s *savedService) SaveVacancy(ctx context.Context, applicantId uint, vacancyId uint) error {
+	vacancy, err := s.vacancyRepo.GetByID(vacancyId)
+	if err != nil {
+		return err
+	}
+	if !vacancy.IsOpen(time.Now()) {
+		return constants.ErrVacancyNotPublished
+	}
+
+	return s.savedVacancyRepo.Save(ctx, applicantId, vacancyId)
+}
+
+func (s *savedService) UnsaveVacancy(ctx context.Context, applicantId uint, vacancyId uint) error {
+	return s.savedVacancyRepo.Delete(ctx, applicantId, vacancyId)
+}
+
+func (s *savedService) SavedVacancies(
+	ctx context.Context,
+	applicantId uint,
+	filter models.SavedVacancyFilter,
+) (*models.SavedVacancyList, error) {
+	return s.savedVacancyRepo.List(ctx, applicantId, filter)
+}
+
+// CreateSearch сохраняет поиск. Первая подборка включит вакансии,
+// появившиеся после сохранения.
+func (s *savedService) CreateSearch(
+	ctx context.Context,
+	applicantId uint,
+	req models.SavedSearchRequest,
+) (*models.SavedSearch, error) {
+	now := time.Now()
+	search := &models.SavedSearch{
+		ApplicantID: applicantId,
+		Name:        strings.TrimSpace(req.Name),
+		Filter:      req.Filter,
+		Frequency:   req.Frequency,
+		LastRunAt:   now,
+		NextRunAt:   now.Add(req.Frequency.Period()),
+	}
+	if err := s.savedSearchRepo.Create(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+func (s *savedService) Searches(ctx context.Context, applicantId uint) ([]models.SavedSearch, error) {
+	return s.savedSearchRepo.List(ctx, applicantId)
+}
+
+// UpdateSearch заменяет название, фильтр и частоту подборок. Следующая
+// подборка отсчитывается от предыдущей с новой частотой.
+func (s *savedService) UpdateSearch(
+	ctx context.Context,
+	applicantId uint,
+	id uint,
+	req models.SavedSearchRequest,
+) (*models.SavedSearch, error) {
+	search, err := s.savedSearchRepo.Get(ctx, applicantId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	search.Name = strings.TrimSpace(req.Name)
+	search.Filter = req.Filter
+	search.Frequency = req.Frequency
+	search.NextRunAt = search.LastRunAt.Add(req.Frequency.Period())
+	if err := s.savedSearchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+func (s *savedService) DeleteSearch(ctx context.Context, applicantId uint, id uint) error {
+	return s.savedSearchRepo.Delete(ctx, applicantId, id)
+}
+
+// SendNextDigest отправляет подборку по одному поиску, срок которого
+// наступил, и возвращает false, если таких поисков нет. Подборка
+// охватывает вакансии, опубликованные с прошлого запуска: черновик,
+// созданный раньше, попадёт в подборку после публикации. Пустая
+// подборка не отправляется, но период всё равно сдвигается.
+func (s *savedService) SendNextDigest(ctx context.Context) (bool, error) {
+	now := time.Now()
+
+	var search *models.SavedSearch
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		search, err = s.savedSearchRepo.ClaimDue(ctx, tx, now)
+		if err != nil || search == nil {
+			return err
+		}
+
+		filter := search.Filter
+		filter.PublishedSince = &search.LastRunAt
+		filter.PublishedBefore = &now
+		filter.Sort = models.VacancySortDate
+		filter.Order = "desc"
+		filter.Limit = digestVacancyLimit
+
+		result, err := s.vacancyRepo.Search(filter)
+		if err != nil {
+			return err
+		}
+		if result.Total > 0 {
+			if err := s.notifications.SavedSearchDigest(ctx, tx, search, result); err != nil {
+				return err
+			}
+		}
+
+		return s.savedSearchRepo.MarkRun(ctx, tx, search.ID, now, now.Add(search.Frequency.Period()))
+	})
+	if err == nil || search == nil {
+		return search != nil, err
+	}
+
+	// Неудачный поиск откладывается на период, чтобы не мешать остальным.
+	err = fmt.Errorf("saved search %d: %w", search.ID, err)
+	if rescheduleErr := s.savedSearchRepo.Reschedule(ctx, search.ID, now.Add(search.Frequency.Period())); rescheduleErr != nil {
+		return false, errors.Join(err, rescheduleErr)
+	}
+	return true, err
+}
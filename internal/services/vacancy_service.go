@@ -1,13 +1,21 @@
 package services
 
 import (
+	"strings"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/repository"
 )
 
 type VacancyService interface {
-	Search(models.VacancyFilter) ([]models.Vacancy, error)
+	Search(models.VacancyFilter) (*models.VacancySearchResult, error)
 	Create(dto models.VacancyCreateRequest) (*models.Vacancy, error)
+	GetByID(id uint) (*models.Vacancy, error)
+	Update(id uint, dto models.VacancyUpdateRequest) (*models.Vacancy, error)
+	ChangeStatus(id uint, status models.VacancyStatus) (*models.Vacancy, error)
+	Delete(id uint) error
 }
 
 type vacancyService struct {
@@ -18,23 +26,118 @@ func NewVacancyService(repo repository.VacancyRepository) VacancyService {
 	return &vacancyService{vacancyRepo: repo}
 }
 
-func (s *vacancyService) Search(filter models.VacancyFilter) ([]models.Vacancy, error) {
+func (s *vacancyService) Search(filter models.VacancyFilter) (*models.VacancySearchResult, error) {
 	return s.vacancyRepo.Search(filter)
 }
 
 func (s *vacancyService) Create(dto models.VacancyCreateRequest) (*models.Vacancy, error) {
+	now := time.Now()
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(now) {
+		return nil, constants.ErrVacancyExpired
+	}
+
 	vacancy := &models.Vacancy{
+		Status:           models.VacancyDraft,
+		ExpiresAt:        dto.ExpiresAt,
 		Title:            dto.Title,
 		Description:      dto.Description,
-		Salary:           dto.Salary,
 		Requirements:     dto.Requirements,
 		Responsibilities: dto.Responsibilities,
 		NiceToHave:       dto.NiceToHave,
+		SalaryMin:        dto.SalaryMin,
+		SalaryMax:        dto.SalaryMax,
+		SalaryCurrency:   dto.SalaryCurrency,
+		SalaryGross:      dto.SalaryGross,
+		City:             strings.TrimSpace(dto.City),
+		WorkMode:         dto.WorkMode,
+		EmploymentType:   dto.EmploymentType,
+		ExperienceLevel:  dto.ExperienceLevel,
 		CompanyID:        dto.CompanyID,
 	}
+	if vacancy.SalaryCurrency == "" {
+		vacancy.SalaryCurrency = models.CurrencyRUB
+	}
+	if vacancy.WorkMode == "" {
+		vacancy.WorkMode = models.WorkModeOffice
+	}
+	if vacancy.EmploymentType == "" {
+		vacancy.EmploymentType = models.EmploymentFullTime
+	}
+	if dto.Publish {
+		vacancy.Status = models.VacancyPublished
+		vacancy.PublishedAt = &now
+	}
 	if err := s.vacancyRepo.Create(vacancy); err != nil {
 		return nil, err
 	}
 
 	return vacancy, nil
 }
+
+func (s *vacancyService) GetByID(id uint) (*models.Vacancy, error) {
+	return s.vacancyRepo.GetByID(id)
+}
+
+func (s *vacancyService) Update(id uint, dto models.VacancyUpdateRequest) (*models.Vacancy, error) {
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(time.Now()) {
+		return nil, constants.ErrVacancyExpired
+	}
+
+	return s.vacancyRepo.Update(id, func(vacancy *models.Vacancy) error {
+		if dto.Title != nil {
+			vacancy.Title = *dto.Title
+		}
+		if dto.Description != nil {
+			vacancy.Description = *dto.Description
+		}
+		if dto.Requirements != nil {
+			vacancy.Requirements = *dto.Requirements
+		}
+		if dto.Responsibilities != nil {
+			vacancy.Responsibilities = *dto.Responsibilities
+		}
+		if dto.NiceToHave != nil {
+			vacancy.NiceToHave = *dto.NiceToHave
+		}
+		if dto.ExpiresAt != nil {
+			vacancy.ExpiresAt = dto.ExpiresAt
+		}
+		if dto.SalaryMin != nil {
+			vacancy.SalaryMin = dto.SalaryMin
+		}
+		if dto.SalaryMax != nil {
+			vacancy.SalaryMax = dto.SalaryMax
+		}
+		if dto.SalaryCurrency != nil {
+			vacancy.SalaryCurrency = *dto.SalaryCurrency
+		}
+		if dto.SalaryGross != nil {
+			vacancy.SalaryGross = *dto.SalaryGross
+		}
+		if dto.City != nil {
+			vacancy.City = strings.TrimSpace(*dto.City)
+		}
+		if dto.WorkMode != nil {
+			vacancy.WorkMode = *dto.WorkMode
+		}
+		if dto.EmploymentType != nil {
+			vacancy.EmploymentType = *dto.EmploymentType
+		}
+		if dto.ExperienceLevel != nil {
+			vacancy.ExperienceLevel = *dto.ExperienceLevel
+		}
+
+		if vacancy.SalaryMin != nil && vacancy.SalaryMax != nil && *vacancy.SalaryMin > *vacancy.SalaryMax {
+			return constants.ErrInvalidSalaryRange
+		}
+		return nil
+	})
+}
+
+func (s *vacancyService) ChangeStatus(id uint, status models.VacancyStatus) (*models.Vacancy, error) {
+	return s.vacancyRepo.ChangeStatus(id, status)
+}
+
+func (s *vacancyService) Delete(id uint) error {
+	return s.vacancyRepo.Delete(id)
+}
@@ -0,0 +1,272 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AdminHandler — консоль модерации. Доступна только субъектам с ролью ADMIN.
+type AdminHandler struct {
+	service        services.AdminService
+	companyService services.CompanyService
+	reviewService  services.ReviewService
+	authService    services.AuthService
+}
+
+func NewAdminHandler(
+	service services.AdminService,
+	companyService services.CompanyService,
+	reviewService services.ReviewService,
+	authService services.AuthService,
+) *AdminHandler {
+	return &AdminHandler{
+		service:        service,
+		companyService: companyService,
+		reviewService:  reviewService,
+		authService:    authService,
+	}
+}
+
+func (h *AdminHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	admin := r.Group("/admin",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.Authorize(models.RoleAdmin),
+	)
+	{
+		admin.GET("/stats", h.Stats)
+		admin.GET("/audit", h.Actions)
+		admin.POST("/impersonate", h.Impersonate)
+
+		admin.GET("/applicants", h.accounts(models.RoleApplicant))
+		admin.PUT("/applicants/:id/block", h.block(models.RoleApplicant))
+		admin.DELETE("/applicants/:id/block", h.unblock(models.RoleApplicant))
+
+		admin.GET("/companies", h.accounts(models.RoleCompany))
+		admin.PUT("/companies/:id/block", h.block(models.RoleCompany))
+		admin.DELETE("/companies/:id/block", h.unblock(models.RoleCompany))
+		admin.PUT("/companies/:id/verification", h.VerifyCompany)
+
+		admin.POST("/vacancies/:id/takedown", h.TakedownVacancy)
+
+		admin.GET("/reviews", h.Reviews)
+		admin.PUT("/reviews/:id/moderation", h.ModerateReview)
+		admin.POST("/reviews/:id/takedown", h.TakedownReview)
+	}
+}
+
+func (h *AdminHandler) Stats(c *gin.Context) {
+	stats, err := h.service.Stats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": stats})
+}
+
+// Actions — журнал действий администраторов, новые записи первыми.
+func (h *AdminHandler) Actions(c *gin.Context) {
+	var filter models.AdminActionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actions, err := h.service.Actions(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": actions.Items,
+		"meta": gin.H{"total": actions.Total},
+	})
+}
+
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	var req models.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	adminId, _ := middlewares.CurrentUser(c)
+	tokens, err := h.service.Impersonate(c.Request.Context(), adminId, req)
+	if err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tokens})
+}
+
+// accounts — поиск соискателей или компаний по имени и почте.
+func (h *AdminHandler) accounts(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var filter models.AccountFilter
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		accounts, err := h.service.Accounts(c.Request.Context(), role, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": accounts.Items,
+			"meta": gin.H{"total": accounts.Total},
+		})
+	}
+}
+
+func (h *AdminHandler) block(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var req models.ModerationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		adminId, _ := middlewares.CurrentUser(c)
+		if err := h.service.Block(c.Request.Context(), adminId, role, uint(id), req.Reason); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	}
+}
+
+func (h *AdminHandler) unblock(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		adminId, _ := middlewares.CurrentUser(c)
+		if err := h.service.Unblock(c.Request.Context(), adminId, role, uint(id)); err != nil {
+			writeAdminError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	}
+}
+
+func (h *AdminHandler) VerifyCompany(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.CompanyVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	company, err := h.companyService.SetVerified(uint(id), *req.Verified)
+	if err != nil {
+		writeCompanyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": company})
+}
+
+func (h *AdminHandler) TakedownVacancy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	adminId, _ := middlewares.CurrentUser(c)
+	vacancy, err := h.service.TakedownVacancy(c.Request.Context(), adminId, uint(id), req.Reason)
+	if err != nil {
+		writeVacancyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": vacancy})
+}
+
+// Reviews — очередь модерации отзывов, по умолчанию ожидающие проверки.
+func (h *AdminHandler) Reviews(c *gin.Context) {
+	var filter models.ReviewFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reviews, err := h.reviewService.ModerationQueue(c.Request.Context(), filter)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": reviews.Items,
+		"meta": gin.H{"total": reviews.Total},
+	})
+}
+
+func (h *AdminHandler) ModerateReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	review, err := h.reviewService.Moderate(c.Request.Context(), uint(id), req)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": review})
+}
+
+func (h *AdminHandler) TakedownReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	adminId, _ := middlewares.CurrentUser(c)
+	review, err := h.service.TakedownReview(c.Request.Context(), adminId, uint(id), req.Reason)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": review})
+}
+
+// writeAdminError переводит ошибки блокировки и входа под пользователем
+// в HTTP-статусы.
+func writeAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrAccountBlocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
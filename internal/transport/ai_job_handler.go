@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AIJobHandler struct {
+	service     services.AIJobService
+	authService services.AuthService
+	logger      *slog.Logger
+}
+
+func NewAIJobHandler(service services.AIJobService, authService services.AuthService, logger *slog.Logger) *AIJobHandler {
+	return &AIJobHandler{service: service, authService: authService, logger: logger}
+}
+
+func (h *AIJobHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	jobs := r.Group("/ai-jobs", middlewares.Authenticate(*jwtService, h.authService))
+	{
+		jobs.GET("/:id", h.GetByID)
+	}
+}
+
+func (h *AIJobHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+
+	userId, role := middlewares.CurrentUser(c)
+	job, err := h.service.GetByID(userId, role, uint(id))
+	switch {
+	case errors.Is(err, constants.ErrForbidden):
+		middlewares.AbortForbidden(c)
+		return
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("не удалось получить ИИ-задачу", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
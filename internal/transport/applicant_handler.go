@@ -8,6 +8,7 @@ import (
 	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/dto"
 	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
 )
@@ -27,9 +28,9 @@ func (h *ApplicantHandler) RegisterRoutes(r *gin.Engine) {
 	applicant := r.Group("/applicant")
 	{
 		applicant.GET("", h.List)
-		applicant.GET("/:id", middlewares.Authenticate(*jwtService), h.GetByID)
-		applicant.PUT("/:id", middlewares.Authenticate(*jwtService), h.Update)
-		applicant.DELETE("/:id", middlewares.Authenticate(*jwtService), h.Delete)
+		applicant.GET("/:id", middlewares.Authenticate(*jwtService, h.authService), h.GetByID)
+		applicant.PUT("/:id", middlewares.Authenticate(*jwtService, h.authService), middlewares.ForbidImpersonation(), middlewares.RequireOwner(models.RoleApplicant, "id", nil), h.Update)
+		applicant.DELETE("/:id", middlewares.Authenticate(*jwtService, h.authService), middlewares.ForbidImpersonation(), middlewares.RequireOwner(models.RoleApplicant, "id", nil), h.Delete)
 	}
 }
 
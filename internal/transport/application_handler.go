@@ -1,25 +1,35 @@
 package transport
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ApplicationHandler struct {
-	service services.ApplicationService
+	service     services.ApplicationService
+	authService services.AuthService
 }
 
-func NewApplicationHandler(service services.ApplicationService) *ApplicationHandler {
-	return &ApplicationHandler{service: service}
+func NewApplicationHandler(service services.ApplicationService, authService services.AuthService) *ApplicationHandler {
+	return &ApplicationHandler{service: service, authService: authService}
 }
 
 func (h *ApplicationHandler) RegisterRoutes(r *gin.Engine) {
-	application := r.Group("/applications")
+	jwtService := h.authService.GetJWTService()
+	application := r.Group("/applications", middlewares.Authenticate(*jwtService, h.authService))
 	{
-		application.POST("", h.Create)
+		application.POST("", middlewares.Authorize(models.RoleApplicant), h.Create)
+		application.POST("/:id/withdraw", middlewares.Authorize(models.RoleApplicant), h.Withdraw)
+		application.GET("/:id/history", h.History)
 	}
 }
 
@@ -29,10 +39,60 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	application, err := h.service.Create(req)
+	userId, _ := middlewares.CurrentUser(c)
+	application, err := h.service.Create(userId, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeApplicationError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"data": application})
 }
+
+func (h *ApplicationHandler) Withdraw(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.WithdrawApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, _ := middlewares.CurrentUser(c)
+	application, err := h.service.Withdraw(userId, uint(id), req)
+	if err != nil {
+		writeApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": application})
+}
+
+func (h *ApplicationHandler) History(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	history, err := h.service.History(userId, role, uint(id))
+	if err != nil {
+		writeApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": history})
+}
+
+// writeApplicationError переводит ошибки работы с откликами в HTTP-статусы.
+func writeApplicationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, constants.ErrForbidden):
+		middlewares.AbortForbidden(c)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrInvalidStatusTransition), errors.Is(err, constants.ErrVacancyNotPublished):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -1,15 +1,21 @@
 package transport
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/AliUmarov/team-find-me-job/internal/constants"
 	"github.com/AliUmarov/team-find-me-job/internal/dto"
+	"github.com/AliUmarov/team-find-me-job/internal/mail"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/pkg/utils"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/AliUmarov/team-find-me-job/internal/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AuthHandler struct {
@@ -19,12 +25,20 @@ type AuthHandler struct {
 }
 
 func (h *AuthHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.service.GetJWTService()
 	authRoutes := r.Group("/auth")
 	{
 		authRoutes.POST("/register", h.Register)
 		authRoutes.POST("/login", h.Login)
+		authRoutes.POST("/company/register", h.RegisterCompany)
+		authRoutes.POST("/company/login", h.LoginCompany)
+		authRoutes.POST("/company/members/login", h.LoginCompanyMember)
+		authRoutes.POST("/company/members/accept", h.AcceptInvitation)
+		authRoutes.POST("/admin/login", h.LoginAdmin)
 		authRoutes.POST("/refresh", h.RefreshToken)
-		authRoutes.POST("/logout", h.Logout)
+		authRoutes.POST("/logout", middlewares.Authenticate(*jwtService, h.service), middlewares.ForbidImpersonation(), h.Logout)
+		authRoutes.GET("/sessions", middlewares.Authenticate(*jwtService, h.service), h.ListSessions)
+		authRoutes.DELETE("/sessions/:id", middlewares.Authenticate(*jwtService, h.service), middlewares.ForbidImpersonation(), h.RevokeSession)
 		authRoutes.POST("/send-verification-email", h.SendVerificationEmail)
 		authRoutes.POST("/verify-email", h.VerifyEmail)
 		authRoutes.POST("/send-password-reset", h.SendPasswordReset)
@@ -49,6 +63,10 @@ func (h *AuthHandler) Register(ctx *gin.Context) {
 		return
 	}
 
+	if req.Locale == "" {
+		req.Locale = string(mail.ParseLanguage(ctx.GetHeader("Accept-Language")))
+	}
+
 	// Validate request
 	if err := h.validation.ValidateRegisterRequest(req); err != nil {
 		res := utils.BuildResponseFailed("Validation failed", err.Error(), nil)
@@ -82,7 +100,49 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 		return
 	}
 
-	result, err := h.service.Login(ctx.Request.Context(), req)
+	result, err := h.service.Login(ctx.Request.Context(), req, clientInfo(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGIN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *AuthHandler) RegisterCompany(ctx *gin.Context) {
+	var req models.CompanyRegisterRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if req.Locale == "" {
+		req.Locale = string(mail.ParseLanguage(ctx.GetHeader("Accept-Language")))
+	}
+
+	result, err := h.service.RegisterCompany(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REGISTER_USER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *AuthHandler) LoginCompany(ctx *gin.Context) {
+	var req dto.CompanyLoginRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	result, err := h.service.LoginCompany(ctx.Request.Context(), req, clientInfo(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGIN, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -93,6 +153,63 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (h *AuthHandler) LoginCompanyMember(ctx *gin.Context) {
+	var req dto.CompanyLoginRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	result, err := h.service.LoginCompanyMember(ctx.Request.Context(), req, clientInfo(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGIN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *AuthHandler) LoginAdmin(ctx *gin.Context) {
+	var req dto.CompanyLoginRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	result, err := h.service.LoginAdmin(ctx.Request.Context(), req, clientInfo(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGIN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *AuthHandler) AcceptInvitation(ctx *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := h.service.AcceptInvitation(ctx.Request.Context(), req, clientInfo(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_ACCEPT_INVITATION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_ACCEPT_INVITATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
 	var req dto.RefreshTokenRequest
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -101,7 +218,7 @@ func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
-	result, err := h.service.RefreshToken(ctx.Request.Context(), req)
+	result, err := h.service.RefreshToken(ctx.Request.Context(), req, clientInfo(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REFRESH_TOKEN, err.Error(), nil)
 		ctx.JSON(http.StatusUnauthorized, res)
@@ -113,9 +230,10 @@ func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
 }
 
 func (h *AuthHandler) Logout(ctx *gin.Context) {
-	userId := ctx.MustGet("user_id").(string)
+	userId := ctx.MustGet("user_id").(uint)
+	role := ctx.GetString("role")
 
-	err := h.service.Logout(ctx.Request.Context(), userId)
+	err := h.service.Logout(ctx.Request.Context(), userId, role)
 	if err != nil {
 		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -126,6 +244,50 @@ func (h *AuthHandler) Logout(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (h *AuthHandler) ListSessions(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(uint)
+	role := ctx.GetString("role")
+
+	jwtService := h.service.GetJWTService()
+	currentSessionId, _ := (*jwtService).GetSessionIDByToken(ctx.GetString("token"))
+
+	sessions, err := h.service.ListSessions(ctx.Request.Context(), userId, role, currentSessionId)
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_GET_SESSIONS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_GET_SESSIONS, sessions)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *AuthHandler) RevokeSession(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(uint)
+	role := ctx.GetString("role")
+
+	sessionId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REVOKE_SESSION, constants.ERR_INCORRECT_ID, nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	err = h.service.RevokeSession(ctx.Request.Context(), userId, role, sessionId)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrSessionNotFound) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(constants.MESSAGE_FAILED_REVOKE_SESSION, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_REVOKE_SESSION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (h *AuthHandler) SendVerificationEmail(ctx *gin.Context) {
 	var req dto.SendVerificationEmailRequest
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -201,3 +363,13 @@ func (h *AuthHandler) ResetPassword(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(constants.MESSAGE_SUCCESS_RESET_PASSWORD, nil)
 	ctx.JSON(http.StatusOK, res)
 }
+
+// clientInfo собирает сведения об устройстве для сессии. Клиент может
+// назвать устройство сам заголовком X-Device-Name.
+func clientInfo(ctx *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		Device:    strings.TrimSpace(ctx.GetHeader("X-Device-Name")),
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+	}
+}
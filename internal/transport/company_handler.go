@@ -1,39 +1,83 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/pkg/utils"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// maxCompanyImageRequest ограничивает тело запроса с изображением;
+// точный лимит для логотипа и обложки проверяет сервис.
+const maxCompanyImageRequest = 6 << 20
+
 type CompanyHandler struct {
-	service services.CompanyService
+	service       services.CompanyService
+	memberService services.MemberService
+	authService   services.AuthService
 }
 
-func NewCompanyHandler(service services.CompanyService) *CompanyHandler {
-	return &CompanyHandler{service: service}
+func NewCompanyHandler(
+	service services.CompanyService,
+	memberService services.MemberService,
+	authService services.AuthService,
+) *CompanyHandler {
+	return &CompanyHandler{service: service, memberService: memberService, authService: authService}
 }
 
 func (h *CompanyHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
 	company := r.Group("/companies")
 	{
-		company.GET(":id/applications/:app/accept", h.AcceptApplication)
-		company.GET(":id/applications/:app/reject", h.RejectApplication)
-		company.GET(":id/applications", h.Applications)
+		owner := company.Group(":id",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.Authorize(models.RoleCompany),
+			middlewares.RequireOwner(models.RoleCompany, "id", nil),
+		)
+		owner.PATCH("", h.Update)
+		owner.DELETE("", middlewares.ForbidImpersonation(), h.Delete)
+		owner.POST("logo", h.uploadImage(models.CompanyLogo))
+		owner.POST("cover", h.uploadImage(models.CompanyCover))
+
+		// Отклики ведёт вся команда; право менять статус отклика
+		// проверяет сервис.
+		team := company.Group(":id",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.RequireCompanyMember("id", h.memberService.Role,
+				models.MemberAdmin, models.MemberRecruiter, models.MemberViewer,
+			),
+		)
+		team.GET("applications/:app/accept", middlewares.AuditImpersonation(), h.AcceptApplication)
+		team.GET("applications/:app/reject", middlewares.AuditImpersonation(), h.RejectApplication)
+		team.PATCH("applications/:app/status", h.ChangeApplicationStatus)
+		team.GET("applications", h.Applications)
+		team.GET("vacancies/all", h.AllVacancies)
+
 		company.GET("", h.List)
-		company.POST("", h.Create)
+		company.POST("",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.Authorize(models.RoleAdmin),
+			h.Create,
+		)
+		company.GET(":id", h.Get)
 		company.GET(":id/vacancies", h.GetVacanciesByCompanyId)
 	}
+
+	r.Static("/"+utils.PATH+"/companies", utils.PATH+"/companies")
 }
 
 func (h *CompanyHandler) RejectApplication(c *gin.Context) {
 	idStr := c.Param("id")
 	appStr := c.Param("app")
-	companyId, err1 := strconv.ParseUint(appStr, 10, 64)
-	appId, err2 := strconv.ParseUint(idStr, 10, 64)
+	companyId, err1 := strconv.ParseUint(idStr, 10, 64)
+	appId, err2 := strconv.ParseUint(appStr, 10, 64)
 	if err1 != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err1.Error()})
 		return
@@ -42,8 +86,8 @@ func (h *CompanyHandler) RejectApplication(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err2.Error()})
 		return
 	}
-	if err := h.service.RejectApplication(uint(companyId), uint(appId)); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.service.RejectApplication(uint(companyId), uint(appId), middlewares.CurrentCompanyActor(c)); err != nil {
+		writeApplicationError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -52,8 +96,8 @@ func (h *CompanyHandler) RejectApplication(c *gin.Context) {
 func (h *CompanyHandler) AcceptApplication(c *gin.Context) {
 	idStr := c.Param("id")
 	appStr := c.Param("app")
-	companyId, err1 := strconv.ParseUint(appStr, 10, 64)
-	appId, err2 := strconv.ParseUint(idStr, 10, 64)
+	companyId, err1 := strconv.ParseUint(idStr, 10, 64)
+	appId, err2 := strconv.ParseUint(appStr, 10, 64)
 	if err1 != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err1.Error()})
 		return
@@ -62,13 +106,37 @@ func (h *CompanyHandler) AcceptApplication(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err2.Error()})
 		return
 	}
-	if err := h.service.AcceptApplication(uint(companyId), uint(appId)); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.service.AcceptApplication(uint(companyId), uint(appId), middlewares.CurrentCompanyActor(c)); err != nil {
+		writeApplicationError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "success"})
 }
 
+func (h *CompanyHandler) ChangeApplicationStatus(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	appId, err := strconv.ParseUint(c.Param("app"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.ChangeApplicationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	application, err := h.service.ChangeApplicationStatus(uint(companyId), uint(appId), middlewares.CurrentCompanyActor(c), req)
+	if err != nil {
+		writeApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": application})
+}
+
 func (h *CompanyHandler) Applications(c *gin.Context) {
 	var applicationsFilter models.ApplicationFilter
 	idStr := c.Param("id")
@@ -96,7 +164,7 @@ func (h *CompanyHandler) GetVacanciesByCompanyId(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	companies, err := h.service.GetVacanciesByCompanyId(uint(id))
+	companies, err := h.service.GetVacanciesByCompanyId(uint(id), models.VacancyPublished)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -104,6 +172,27 @@ func (h *CompanyHandler) GetVacanciesByCompanyId(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": companies})
 }
 
+// AllVacancies возвращает владельцу вакансии компании в любом статусе
+// или только в статусе ?status=.
+func (h *CompanyHandler) AllVacancies(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var filter models.CompanyVacancyFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	vacancies, err := h.service.GetVacanciesByCompanyId(uint(id), filter.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": vacancies})
+}
+
 func (h *CompanyHandler) List(c *gin.Context) {
 	companies, err := h.service.List()
 	if err != nil {
@@ -113,6 +202,8 @@ func (h *CompanyHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": companies})
 }
 
+// Create заводит компанию без входа. Доступно только администратору
+// платформы: сами компании регистрируются через /auth/company/register.
 func (h *CompanyHandler) Create(c *gin.Context) {
 	var req models.CompanyCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -126,3 +217,101 @@ func (h *CompanyHandler) Create(c *gin.Context) {
 	}
 	c.JSON(http.StatusCreated, gin.H{"data": company})
 }
+
+func (h *CompanyHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	company, err := h.service.Get(uint(id))
+	if err != nil {
+		writeCompanyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": company})
+}
+
+func (h *CompanyHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.CompanyUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	company, err := h.service.Update(uint(id), req)
+	if err != nil {
+		writeCompanyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": company})
+}
+
+// uploadImage принимает изображение в поле file формы.
+func (h *CompanyHandler) uploadImage(kind models.CompanyImage) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCompanyImageRequest)
+		header, err := c.FormFile("file")
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrImageTooLarge.Error()})
+			return
+		case err != nil:
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_FILE})
+			return
+		}
+
+		company, err := h.service.UploadImage(uint(id), kind, header)
+		if err != nil {
+			writeCompanyError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": company})
+	}
+}
+
+// Delete мягко удаляет компанию и завершает все её сессии. Сессии
+// завершаются только после удаления: при ошибке компания остаётся
+// в системе с рабочим входом.
+func (h *CompanyHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	if err := h.service.Delete(uint(id)); err != nil {
+		writeCompanyError(c, err)
+		return
+	}
+	if err := h.authService.Logout(ctx, uint(id), models.RoleCompany); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// writeCompanyError переводит ошибки работы с профилем компании в HTTP-статусы.
+func writeCompanyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrUnsupportedImage):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,120 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/dto"
+	"github.com/AliUmarov/team-find-me-job/internal/events"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// eventHeartbeat — период комментариев-пингов, чтобы прокси
+// не закрывали соединение без событий.
+const eventHeartbeat = 25 * time.Second
+
+type EventHandler struct {
+	broker        events.Broker
+	memberService services.MemberService
+	authService   services.AuthService
+}
+
+func NewEventHandler(
+	broker events.Broker,
+	memberService services.MemberService,
+	authService services.AuthService,
+) *EventHandler {
+	return &EventHandler{broker: broker, memberService: memberService, authService: authService}
+}
+
+func (h *EventHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	r.POST("/events/ticket", middlewares.Authenticate(*jwtService, h.authService), h.Ticket)
+	r.GET("/events", h.Stream)
+}
+
+// Ticket выдаёт одноразовый билет для /events?ticket=...: access-токен
+// в адресе запроса попал бы в журналы.
+func (h *EventHandler) Ticket(c *gin.Context) {
+	userId, role := middlewares.CurrentUser(c)
+	ticket, err := h.authService.IssueStreamTicket(c.Request.Context(), userId, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"ticket": ticket}})
+}
+
+// Stream отдаёт события владельца билета в формате Server-Sent Events.
+// События об откликах публикуются для компании, поэтому сотрудник
+// подписывается на поток своей компании.
+func (h *EventHandler) Stream(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId, role, err := h.authService.ConsumeStreamTicket(ctx, c.Query("ticket"))
+	if err != nil {
+		writeEventError(c, err)
+		return
+	}
+
+	to := events.Recipient{UserID: userId, Role: role}
+	if role == models.RoleCompanyMember {
+		companyId, err := h.memberService.CompanyOf(ctx, userId)
+		if err != nil {
+			writeEventError(c, err)
+			return
+		}
+		to = events.Recipient{UserID: companyId, Role: models.RoleCompany}
+	}
+	stream := h.broker.Subscribe(ctx, to)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-stream:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatUint(event.ID, 10),
+				Event: event.Type,
+				Data:  event.Data,
+			})
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func writeEventError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, dto.ErrTokenInvalid), errors.Is(err, dto.ErrTokenExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrAccountBlocked),
+		errors.Is(err, constants.ErrMemberNotFound),
+		errors.Is(err, constants.ErrMemberNotActive):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,233 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type InterviewHandler struct {
+	service     services.InterviewService
+	authService services.AuthService
+}
+
+func NewInterviewHandler(service services.InterviewService, authService services.AuthService) *InterviewHandler {
+	return &InterviewHandler{service: service, authService: authService}
+}
+
+func (h *InterviewHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	// Права сотрудника по его роли в команде проверяет сервис.
+	slots := r.Group("/vacancies/:id/slots",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.Authorize(models.RoleCompany, models.RoleCompanyMember),
+	)
+	{
+		slots.POST("", h.CreateSlot)
+		slots.GET("", h.Slots)
+		slots.DELETE("/:slot", h.DeleteSlot)
+	}
+
+	interview := r.Group("/applications/:id/interview", middlewares.Authenticate(*jwtService, h.authService))
+	{
+		interview.GET("", h.Get)
+		interview.POST("", middlewares.Authorize(models.RoleApplicant), h.Book)
+		interview.GET("/slots", h.AvailableSlots)
+		interview.POST("/reschedule", h.Reschedule)
+		interview.POST("/cancel", h.Cancel)
+		interview.GET("/ics", h.Calendar)
+	}
+}
+
+func (h *InterviewHandler) CreateSlot(c *gin.Context) {
+	vacancyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.CreateInterviewSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	slot, err := h.service.CreateSlot(c.Request.Context(), userId, role, uint(vacancyId), req)
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": slot})
+}
+
+func (h *InterviewHandler) Slots(c *gin.Context) {
+	vacancyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var filter models.InterviewSlotFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	slots, err := h.service.Slots(c.Request.Context(), userId, role, uint(vacancyId), filter)
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": slots})
+}
+
+func (h *InterviewHandler) DeleteSlot(c *gin.Context) {
+	vacancyId, err1 := strconv.ParseUint(c.Param("id"), 10, 64)
+	slotId, err2 := strconv.ParseUint(c.Param("slot"), 10, 64)
+	if err := errors.Join(err1, err2); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	if err := h.service.DeleteSlot(c.Request.Context(), userId, role, uint(vacancyId), uint(slotId)); err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *InterviewHandler) AvailableSlots(c *gin.Context) {
+	appId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	slots, err := h.service.AvailableSlots(c.Request.Context(), userId, role, uint(appId))
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": slots})
+}
+
+func (h *InterviewHandler) Get(c *gin.Context) {
+	appId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	interview, err := h.service.Get(c.Request.Context(), userId, role, uint(appId))
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": interview})
+}
+
+func (h *InterviewHandler) Book(c *gin.Context) {
+	appId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.BookInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, _ := middlewares.CurrentUser(c)
+	interview, err := h.service.Book(c.Request.Context(), userId, uint(appId), req)
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": interview})
+}
+
+func (h *InterviewHandler) Reschedule(c *gin.Context) {
+	appId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.BookInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	interview, err := h.service.Reschedule(c.Request.Context(), userId, role, uint(appId), req)
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": interview})
+}
+
+func (h *InterviewHandler) Cancel(c *gin.Context) {
+	appId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.CancelInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	interview, err := h.service.Cancel(c.Request.Context(), userId, role, uint(appId), req)
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": interview})
+}
+
+func (h *InterviewHandler) Calendar(c *gin.Context) {
+	appId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	event, err := h.service.Calendar(c.Request.Context(), userId, role, uint(appId))
+	if err != nil {
+		writeInterviewError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%d.ics"`, appId))
+	c.Data(http.StatusOK, event.ContentType(), event.Marshal())
+}
+
+// writeInterviewError переводит ошибки записи на собеседования в HTTP-статусы.
+func writeInterviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, constants.ErrForbidden):
+		middlewares.AbortForbidden(c)
+	case errors.Is(err, gorm.ErrRecordNotFound),
+		errors.Is(err, constants.ErrSlotNotFound),
+		errors.Is(err, constants.ErrInterviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrSlotOverlaps),
+		errors.Is(err, constants.ErrSlotUnavailable),
+		errors.Is(err, constants.ErrSlotBooked),
+		errors.Is(err, constants.ErrInterviewConflict),
+		errors.Is(err, constants.ErrInterviewExists),
+		errors.Is(err, constants.ErrInterviewNotAllowed),
+		errors.Is(err, constants.ErrInterviewStarted),
+		errors.Is(err, constants.ErrInvalidStatusTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
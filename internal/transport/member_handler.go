@@ -0,0 +1,160 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type MemberHandler struct {
+	service     services.MemberService
+	authService services.AuthService
+}
+
+func NewMemberHandler(service services.MemberService, authService services.AuthService) *MemberHandler {
+	return &MemberHandler{service: service, authService: authService}
+}
+
+func (h *MemberHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+
+	team := r.Group("/companies/:id/members",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.RequireCompanyMember("id", h.service.Role,
+			models.MemberAdmin, models.MemberRecruiter, models.MemberViewer,
+		),
+	)
+	{
+		team.GET("", h.List)
+		// Права на удаление конкретного сотрудника проверяет сервис:
+		// любой сотрудник может выйти из команды сам.
+		team.DELETE("/:member", middlewares.ForbidImpersonation(), h.Remove)
+	}
+
+	admins := r.Group("/companies/:id/members",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.RequireCompanyMember("id", h.service.Role, models.MemberAdmin),
+	)
+	{
+		admins.POST("/invitations", h.Invite)
+		admins.PUT("/:member/vacancies/:vacancy", h.AssignVacancy)
+		admins.DELETE("/:member/vacancies/:vacancy", h.UnassignVacancy)
+	}
+}
+
+func (h *MemberHandler) List(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	members, err := h.service.List(c.Request.Context(), uint(companyId))
+	if err != nil {
+		writeMemberError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": members})
+}
+
+func (h *MemberHandler) Invite(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	member, err := h.service.Invite(c.Request.Context(), uint(companyId), middlewares.CurrentCompanyActor(c), req)
+	if err != nil {
+		writeMemberError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": member})
+}
+
+// Remove исключает сотрудника и завершает все его сессии.
+func (h *MemberHandler) Remove(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	memberId, err := strconv.ParseUint(c.Param("member"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	if err := h.service.Remove(ctx, uint(companyId), middlewares.CurrentCompanyActor(c), uint(memberId)); err != nil {
+		writeMemberError(c, err)
+		return
+	}
+	if err := h.authService.Logout(ctx, uint(memberId), models.RoleCompanyMember); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+func (h *MemberHandler) AssignVacancy(c *gin.Context) {
+	h.changeAssignment(c, h.service.AssignVacancy)
+}
+
+func (h *MemberHandler) UnassignVacancy(c *gin.Context) {
+	h.changeAssignment(c, h.service.UnassignVacancy)
+}
+
+// changeAssignment разбирает параметры пути и назначает рекрутера
+// на вакансию или снимает с неё.
+func (h *MemberHandler) changeAssignment(
+	c *gin.Context,
+	change func(ctx context.Context, companyId uint, memberId uint, vacancyId uint) error,
+) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	memberId, err := strconv.ParseUint(c.Param("member"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	vacancyId, err := strconv.ParseUint(c.Param("vacancy"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := change(c.Request.Context(), uint(companyId), uint(memberId), uint(vacancyId)); err != nil {
+		writeMemberError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// writeMemberError переводит ошибки работы с командой компании в HTTP-статусы.
+func writeMemberError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, constants.ErrMemberNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrMemberExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrMemberNotRecruiter):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
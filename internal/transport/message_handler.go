@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MessageHandler struct {
+	service     services.MessageService
+	authService services.AuthService
+}
+
+func NewMessageHandler(service services.MessageService, authService services.AuthService) *MessageHandler {
+	return &MessageHandler{service: service, authService: authService}
+}
+
+func (h *MessageHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	thread := r.Group("/applications/:id/messages", middlewares.Authenticate(*jwtService, h.authService))
+	{
+		thread.GET("", h.List)
+		thread.POST("", h.Send)
+		thread.POST("/read", h.MarkRead)
+	}
+
+	r.GET("/messages/unread", middlewares.Authenticate(*jwtService, h.authService), h.Unread)
+}
+
+func (h *MessageHandler) List(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var filter models.MessageFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	thread, err := h.service.List(c.Request.Context(), userId, role, uint(id), filter)
+	if err != nil {
+		writeApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": thread})
+}
+
+func (h *MessageHandler) Send(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	message, err := h.service.Send(c.Request.Context(), userId, role, uint(id), req)
+	if err != nil {
+		writeApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": message})
+}
+
+func (h *MessageHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	updated, err := h.service.MarkRead(c.Request.Context(), userId, role, uint(id))
+	if err != nil {
+		writeApplicationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"updated": updated}})
+}
+
+func (h *MessageHandler) Unread(c *gin.Context) {
+	userId, role := middlewares.CurrentUser(c)
+	summary, err := h.service.Unread(c.Request.Context(), userId, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": summary})
+}
@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	service     services.NotificationService
+	authService services.AuthService
+}
+
+func NewNotificationHandler(service services.NotificationService, authService services.AuthService) *NotificationHandler {
+	return &NotificationHandler{service: service, authService: authService}
+}
+
+func (h *NotificationHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	notifications := r.Group("/notifications", middlewares.Authenticate(*jwtService, h.authService))
+	{
+		notifications.GET("", h.List)
+		notifications.GET("/unread-count", h.UnreadCount)
+		notifications.POST("/:id/read", h.MarkRead)
+		notifications.POST("/read-all", h.MarkAllRead)
+		notifications.GET("/preferences", h.Preferences)
+		notifications.PUT("/preferences", h.UpdatePreferences)
+	}
+}
+
+func (h *NotificationHandler) List(c *gin.Context) {
+	var filter models.NotificationFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	list, err := h.service.List(c.Request.Context(), userId, role, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	userId, role := middlewares.CurrentUser(c)
+	unread, err := h.service.UnreadCount(c.Request.Context(), userId, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"unread": unread}})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	err = h.service.MarkRead(c.Request.Context(), userId, role, uint(id))
+	if errors.Is(err, constants.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userId, role := middlewares.CurrentUser(c)
+	updated, err := h.service.MarkAllRead(c.Request.Context(), userId, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"updated": updated}})
+}
+
+func (h *NotificationHandler) Preferences(c *gin.Context) {
+	userId, role := middlewares.CurrentUser(c)
+	preferences, err := h.service.Preferences(c.Request.Context(), userId, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": preferences})
+}
+
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req models.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, role := middlewares.CurrentUser(c)
+	preferences, err := h.service.UpdatePreferences(c.Request.Context(), userId, role, req)
+	if errors.Is(err, constants.ErrUnknownNotificationType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": preferences})
+}
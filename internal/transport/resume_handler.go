@@ -1,38 +1,81 @@
 package transport
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
 
 	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/export"
+	"github.com/AliUmarov/team-find-me-job/internal/extract"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// maxResumeImportSize — предельный размер загружаемого PDF/DOCX.
+const maxResumeImportSize = 5 << 20
+
 type ResumeHandler struct {
-	service services.ResumeService
-	logger  *slog.Logger
+	service     services.ResumeService
+	authService services.AuthService
+	logger      *slog.Logger
 }
 
-func NewResumeHandler(service services.ResumeService, logger *slog.Logger) *ResumeHandler {
+func NewResumeHandler(service services.ResumeService, authService services.AuthService, logger *slog.Logger) *ResumeHandler {
 	return &ResumeHandler{
-		service: service,
-		logger:  logger,
+		service:     service,
+		authService: authService,
+		logger:      logger,
 	}
 }
 
 func (h *ResumeHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
 	api := r.Group("/resumes")
 	{
 		api.GET("/", h.GetAll)
-		api.PATCH("/:id", h.Update)
-		api.DELETE("/:id", h.Delete)
-		api.POST("/:id/improve", h.Improve)
+
+		owner := api.Group("/:id",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.Authorize(models.RoleApplicant),
+			middlewares.RequireOwner(models.RoleApplicant, "id", h.resumeOwner),
+		)
+		owner.PATCH("", h.Update)
+		owner.DELETE("", h.Delete)
+		owner.POST("/improve", h.Improve)
+		owner.GET("/versions", h.Versions)
+		owner.GET("/versions/:version", h.Version)
+		owner.POST("/versions/:version/restore", h.RestoreVersion)
+		owner.GET("/diff", h.DiffVersions)
+		owner.POST("/accept-ai", h.AcceptAISuggestion)
+		owner.GET("/export", h.Export)
 	}
 
-	r.POST("/applicant/:id/resumes", h.Create)
+	r.POST("/applicant/:id/resumes",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.RequireOwner(models.RoleApplicant, "id", nil),
+		h.Create,
+	)
+	r.POST("/applicant/:id/resumes/import",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.RequireOwner(models.RoleApplicant, "id", nil),
+		h.ImportDraft,
+	)
+}
+
+// resumeOwner возвращает ID соискателя, которому принадлежит резюме.
+func (h *ResumeHandler) resumeOwner(id uint) (uint, error) {
+	resume, err := h.service.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+	return resume.ApplicantID, nil
 }
 
 func (h *ResumeHandler) Create(c *gin.Context) {
@@ -184,9 +227,10 @@ func (h *ResumeHandler) Improve(c *gin.Context) {
 		return
 	}
 
-	resume, err := h.service.ImproveResume(uint(idUint))
+	userId, _ := middlewares.CurrentUser(c)
+	job, err := h.service.ImproveResume(userId, uint(idUint))
 	if err != nil {
-		h.logger.Error("ошибка улучшения резюме", slog.Any("error", err))
+		h.logger.Error("ошибка постановки улучшения резюме в очередь", slog.Any("error", err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "не удалось улучшить резюме",
 			"details": err.Error(),
@@ -194,6 +238,211 @@ func (h *ResumeHandler) Improve(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("резюме улучшено", slog.Uint64("resume_id", uint64(idUint)))
+	h.logger.Info("улучшение резюме поставлено в очередь",
+		slog.Uint64("resume_id", uint64(idUint)),
+		slog.Uint64("job_id", uint64(job.ID)),
+	)
+	c.JSON(http.StatusAccepted, gin.H{"job_id": job.ID, "status": job.Status})
+}
+
+func (h *ResumeHandler) Versions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+
+	versions, err := h.service.Versions(uint(id))
+	if err != nil {
+		h.writeVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+func (h *ResumeHandler) Version(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_VERSION})
+		return
+	}
+
+	version, err := h.service.Version(uint(id), number)
+	if err != nil {
+		h.writeVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// DiffVersions сравнивает версии ?from= и ?to= по полям.
+func (h *ResumeHandler) DiffVersions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_VERSION})
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_VERSION})
+		return
+	}
+
+	diff, err := h.service.DiffVersions(uint(id), from, to)
+	if err != nil {
+		h.writeVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func (h *ResumeHandler) RestoreVersion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_VERSION})
+		return
+	}
+
+	resume, err := h.service.RestoreVersion(uint(id), number)
+	if err != nil {
+		h.writeVersionError(c, err)
+		return
+	}
+
+	h.logger.Info("резюме восстановлено из версии",
+		slog.Uint64("resume_id", id),
+		slog.Int("version", number),
+	)
 	c.JSON(http.StatusOK, resume)
 }
+
+func (h *ResumeHandler) AcceptAISuggestion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+
+	resume, err := h.service.AcceptAISuggestion(uint(id))
+	if err != nil {
+		h.writeVersionError(c, err)
+		return
+	}
+
+	h.logger.Info("предложение ИИ принято", slog.Uint64("resume_id", id))
+	c.JSON(http.StatusOK, resume)
+}
+
+func (h *ResumeHandler) writeVersionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrNoAISuggestion):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("ошибка работы с версиями резюме",
+			slog.String("path", c.FullPath()),
+			slog.Any("error", err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ERR_CAN_NOT_GET_RESUME})
+	}
+}
+
+func (h *ResumeHandler) Export(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_ID})
+		return
+	}
+
+	var req models.ResumeExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := h.service.Export(uint(id), req)
+	switch {
+	case errors.Is(err, export.ErrUnknownTemplate), errors.Is(err, export.ErrUnknownFormat):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, export.ErrPDFUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": export.ErrPDFUnavailable.Error()})
+		return
+	case err != nil:
+		h.writeVersionError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="resume-%d.%s"`, id, file.Extension))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// ImportDraft принимает PDF или DOCX в поле file формы и возвращает
+// черновик ResumeCreateRequest. Резюме при этом не создаётся.
+func (h *ResumeHandler) ImportDraft(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxResumeImportSize+1<<20)
+
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ERR_FILE_TOO_LARGE})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_FILE})
+		return
+	case header.Size > maxResumeImportSize:
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ERR_FILE_TOO_LARGE})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_FILE})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ERR_INVALID_FILE})
+		return
+	}
+
+	draft, err := h.service.ImportDraft(c.Request.Context(), header.Filename, data)
+	switch {
+	case errors.Is(err, extract.ErrUnsupportedFormat), errors.Is(err, extract.ErrEncrypted):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, extract.ErrNoText), errors.Is(err, extract.ErrUnreadable):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "не удалось разобрать резюме",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
@@ -0,0 +1,145 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReviewHandler struct {
+	service       services.ReviewService
+	memberService services.MemberService
+	authService   services.AuthService
+}
+
+func NewReviewHandler(
+	service services.ReviewService,
+	memberService services.MemberService,
+	authService services.AuthService,
+) *ReviewHandler {
+	return &ReviewHandler{service: service, memberService: memberService, authService: authService}
+}
+
+func (h *ReviewHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+	reviews := r.Group("/companies/:id/reviews")
+	{
+		reviews.GET("", h.List)
+		reviews.POST("",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.Authorize(models.RoleApplicant),
+			h.Create,
+		)
+		reviews.DELETE("/:review",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.Authorize(models.RoleApplicant),
+			h.Delete,
+		)
+		reviews.POST("/:review/reply",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.RequireCompanyMember("id", h.memberService.Role, models.MemberAdmin),
+			h.Reply,
+		)
+	}
+}
+
+func (h *ReviewHandler) List(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var filter models.ReviewFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reviews, err := h.service.List(c.Request.Context(), uint(companyId), filter)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": reviews.Items,
+		"meta": gin.H{"total": reviews.Total},
+	})
+}
+
+func (h *ReviewHandler) Create(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, _ := middlewares.CurrentUser(c)
+	review, err := h.service.Create(c.Request.Context(), userId, uint(companyId), req)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": review})
+}
+
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	reviewId, err := strconv.ParseUint(c.Param("review"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, _ := middlewares.CurrentUser(c)
+	if err := h.service.Delete(c.Request.Context(), userId, uint(reviewId)); err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+func (h *ReviewHandler) Reply(c *gin.Context) {
+	companyId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reviewId, err := strconv.ParseUint(c.Param("review"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.ReplyReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	review, err := h.service.Reply(c.Request.Context(), uint(companyId), uint(reviewId), req)
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": review})
+}
+
+// writeReviewError переводит ошибки работы с отзывами в HTTP-статусы.
+func writeReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, constants.ErrReviewNotAllowed):
+		middlewares.AbortForbidden(c)
+	case errors.Is(err, constants.ErrReviewNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrReviewExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
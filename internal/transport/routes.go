@@ -3,6 +3,7 @@ package transport
 import (
 	"log/slog"
 
+	"github.com/AliUmarov/team-find-me-job/internal/events"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
 )
@@ -16,14 +17,32 @@ func RegisterRoutes(
 	vacancyService services.VacancyService,
 	applicationService services.ApplicationService,
 	authService services.AuthService,
+	aiJobService services.AIJobService,
+	notificationService services.NotificationService,
+	messageService services.MessageService,
+	interviewService services.InterviewService,
+	reviewService services.ReviewService,
+	memberService services.MemberService,
+	adminService services.AdminService,
+	savedService services.SavedService,
+	broker events.Broker,
 ) {
 	authHandler := NewAuthHandler(authService, logger)
 
-	companyHandler := NewCompanyHandler(companyService)
-	resumeHandler := NewResumeHandler(resumeService, logger)
+	companyHandler := NewCompanyHandler(companyService, memberService, authService)
+	resumeHandler := NewResumeHandler(resumeService, authService, logger)
 	applicantHandler := NewApplicantHandler(applicantService, authService, logger)
-	vacancyHandler := NewVacancyHandler(vacancyService)
-	applicationHandler := NewApplicationHandler(applicationService)
+	vacancyHandler := NewVacancyHandler(vacancyService, authService)
+	applicationHandler := NewApplicationHandler(applicationService, authService)
+	aiJobHandler := NewAIJobHandler(aiJobService, authService, logger)
+	notificationHandler := NewNotificationHandler(notificationService, authService)
+	messageHandler := NewMessageHandler(messageService, authService)
+	interviewHandler := NewInterviewHandler(interviewService, authService)
+	eventHandler := NewEventHandler(broker, memberService, authService)
+	reviewHandler := NewReviewHandler(reviewService, memberService, authService)
+	memberHandler := NewMemberHandler(memberService, authService)
+	adminHandler := NewAdminHandler(adminService, companyService, reviewService, authService)
+	savedHandler := NewSavedHandler(savedService, authService)
 
 	companyHandler.RegisterRoutes(router)
 	applicantHandler.RegisterRoutes(router)
@@ -32,4 +51,13 @@ func RegisterRoutes(
 
 	authHandler.RegisterRoutes(router)
 	applicationHandler.RegisterRoutes(router)
+	aiJobHandler.RegisterRoutes(router)
+	notificationHandler.RegisterRoutes(router)
+	messageHandler.RegisterRoutes(router)
+	interviewHandler.RegisterRoutes(router)
+	reviewHandler.RegisterRoutes(router)
+	memberHandler.RegisterRoutes(router)
+	savedHandler.RegisterRoutes(router)
+	eventHandler.RegisterRoutes(router)
+	adminHandler.RegisterRoutes(router)
 }
@@ -0,0 +1,169 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SavedHandler — закладки и сохранённые поиски текущего соискателя.
+type SavedHandler struct {
+	service     services.SavedService
+	authService services.AuthService
+}
+
+func NewSavedHandler(service services.SavedService, authService services.AuthService) *SavedHandler {
+	return &SavedHandler{service: service, authService: authService}
+}
+
+func (h *SavedHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
+
+	vacancies := r.Group("/saved-vacancies",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.Authorize(models.RoleApplicant),
+	)
+	{
+		vacancies.GET("", h.SavedVacancies)
+		vacancies.PUT("/:id", h.SaveVacancy)
+		vacancies.DELETE("/:id", h.UnsaveVacancy)
+	}
+
+	searches := r.Group("/saved-searches",
+		middlewares.Authenticate(*jwtService, h.authService),
+		middlewares.Authorize(models.RoleApplicant),
+	)
+	{
+		searches.GET("", h.Searches)
+		searches.POST("", h.CreateSearch)
+		searches.PUT("/:id", h.UpdateSearch)
+		searches.DELETE("/:id", h.DeleteSearch)
+	}
+}
+
+func (h *SavedHandler) SavedVacancies(c *gin.Context) {
+	var filter models.SavedVacancyFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	applicantId, _ := middlewares.CurrentUser(c)
+	saved, err := h.service.SavedVacancies(c.Request.Context(), applicantId, filter)
+	if err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": saved.Items,
+		"meta": gin.H{"total": saved.Total},
+	})
+}
+
+// SaveVacancy добавляет вакансию в закладки; повторный запрос ничего не меняет.
+func (h *SavedHandler) SaveVacancy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	applicantId, _ := middlewares.CurrentUser(c)
+	if err := h.service.SaveVacancy(c.Request.Context(), applicantId, uint(id)); err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SavedHandler) UnsaveVacancy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	applicantId, _ := middlewares.CurrentUser(c)
+	if err := h.service.UnsaveVacancy(c.Request.Context(), applicantId, uint(id)); err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SavedHandler) Searches(c *gin.Context) {
+	applicantId, _ := middlewares.CurrentUser(c)
+	searches, err := h.service.Searches(c.Request.Context(), applicantId)
+	if err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": searches})
+}
+
+func (h *SavedHandler) CreateSearch(c *gin.Context) {
+	var req models.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	applicantId, _ := middlewares.CurrentUser(c)
+	search, err := h.service.CreateSearch(c.Request.Context(), applicantId, req)
+	if err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": search})
+}
+
+func (h *SavedHandler) UpdateSearch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	applicantId, _ := middlewares.CurrentUser(c)
+	search, err := h.service.UpdateSearch(c.Request.Context(), applicantId, uint(id), req)
+	if err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": search})
+}
+
+func (h *SavedHandler) DeleteSearch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	applicantId, _ := middlewares.CurrentUser(c)
+	if err := h.service.DeleteSearch(c.Request.Context(), applicantId, uint(id)); err != nil {
+		writeSavedError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// writeSavedError переводит ошибки закладок и сохранённых поисков в HTTP-статусы.
+func writeSavedError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, constants.ErrSavedSearchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrVacancyNotPublished):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrSavedSearchLimit):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,27 +1,55 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/AliUmarov/team-find-me-job/internal/constants"
+	"github.com/AliUmarov/team-find-me-job/internal/middlewares"
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/AliUmarov/team-find-me-job/internal/services"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type VacancyHandler struct {
-	service services.VacancyService
+	service     services.VacancyService
+	authService services.AuthService
 }
 
-func NewVacancyHandler(service services.VacancyService) *VacancyHandler {
-	return &VacancyHandler{service: service}
+func NewVacancyHandler(service services.VacancyService, authService services.AuthService) *VacancyHandler {
+	return &VacancyHandler{service: service, authService: authService}
 }
 
 func (h *VacancyHandler) RegisterRoutes(r *gin.Engine) {
+	jwtService := h.authService.GetJWTService()
 	vacancy := r.Group("/vacancies")
 	{
 		vacancy.GET("", h.Search)
-		vacancy.POST("", h.Create)
+		vacancy.POST("", middlewares.Authenticate(*jwtService, h.authService), middlewares.Authorize(models.RoleCompany), h.Create)
+
+		owner := vacancy.Group("/:id",
+			middlewares.Authenticate(*jwtService, h.authService),
+			middlewares.Authorize(models.RoleCompany),
+			middlewares.RequireOwner(models.RoleCompany, "id", h.vacancyOwner),
+		)
+		owner.PATCH("", h.Update)
+		owner.DELETE("", h.Delete)
+		owner.POST("/publish", h.changeStatus(models.VacancyPublished))
+		owner.POST("/pause", h.changeStatus(models.VacancyPaused))
+		owner.POST("/close", h.changeStatus(models.VacancyClosed))
+		owner.POST("/archive", h.changeStatus(models.VacancyArchived))
+	}
+}
+
+// vacancyOwner возвращает ID компании, которой принадлежит вакансия.
+func (h *VacancyHandler) vacancyOwner(id uint) (uint, error) {
+	vacancy, err := h.service.GetByID(id)
+	if err != nil {
+		return 0, err
 	}
+	return vacancy.CompanyID, nil
 }
 
 func (h *VacancyHandler) Search(c *gin.Context) {
@@ -30,12 +58,22 @@ func (h *VacancyHandler) Search(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	vacancies, err := h.service.Search(filter)
-	if err != nil {
+	result, err := h.service.Search(filter)
+	if errors.Is(err, constants.ErrInvalidCursor) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": vacancies})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": result.Items,
+		"meta": gin.H{
+			"total":       result.Total,
+			"next_cursor": result.NextCursor,
+		},
+	})
 }
 
 func (h *VacancyHandler) Create(c *gin.Context) {
@@ -44,6 +82,10 @@ func (h *VacancyHandler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if companyId, _ := middlewares.CurrentUser(c); req.CompanyID != companyId {
+		middlewares.AbortForbidden(c)
+		return
+	}
 	vacancy, err := h.service.Create(req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -51,3 +93,65 @@ func (h *VacancyHandler) Create(c *gin.Context) {
 	}
 	c.JSON(http.StatusCreated, gin.H{"data": vacancy})
 }
+
+func (h *VacancyHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req models.VacancyUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	vacancy, err := h.service.Update(uint(id), req)
+	if err != nil {
+		writeVacancyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": vacancy})
+}
+
+func (h *VacancyHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.Delete(uint(id)); err != nil {
+		writeVacancyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+func (h *VacancyHandler) changeStatus(status models.VacancyStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		vacancy, err := h.service.ChangeStatus(uint(id), status)
+		if err != nil {
+			writeVacancyError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": vacancy})
+	}
+}
+
+// writeVacancyError переводит ошибки работы с вакансиями в HTTP-статусы.
+func writeVacancyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, constants.ErrInvalidVacancyTransition),
+		errors.Is(err, constants.ErrVacancyNotEditable),
+		errors.Is(err, constants.ErrVacancyNotDraft):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
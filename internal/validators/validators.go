@@ -1,6 +1,10 @@
 package validators
 
 import (
+	"net/url"
+	"os"
+	"strings"
+
 	"github.com/AliUmarov/team-find-me-job/internal/models"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
@@ -9,15 +13,95 @@ import (
 func RegisterValidators() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("application_status", validateApplicationStatus)
+		v.RegisterValidation("skill_level", validateSkillLevel)
+		v.RegisterValidation("language_proficiency", validateLanguageProficiency)
+		v.RegisterValidation("currency", validateCurrency)
+		v.RegisterValidation("work_mode", validateWorkMode)
+		v.RegisterValidation("employment_type", validateEmploymentType)
+		v.RegisterValidation("experience_level", validateExperienceLevel)
+		v.RegisterValidation("member_role", validateMemberRole)
+		v.RegisterValidation("attachment_url", validateAttachmentURL)
+		v.RegisterStructValidation(validateVacancyCreateSalary, models.VacancyCreateRequest{})
+		v.RegisterStructValidation(validateVacancyUpdateSalary, models.VacancyUpdateRequest{})
 	}
 }
 
 func validateApplicationStatus(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
 
-	switch models.ApplicationStatus(value) {
-	case models.StatusPending, models.StatusReviewed, models.StatusAccepted, models.StatusRejected:
+	return models.ApplicationStatus(value).IsValid()
+}
+
+func validateSkillLevel(fl validator.FieldLevel) bool {
+	return models.SkillLevel(fl.Field().String()).IsValid()
+}
+
+func validateLanguageProficiency(fl validator.FieldLevel) bool {
+	return models.LanguageProficiency(fl.Field().String()).IsValid()
+}
+
+func validateCurrency(fl validator.FieldLevel) bool {
+	return models.Currency(fl.Field().String()).IsValid()
+}
+
+func validateWorkMode(fl validator.FieldLevel) bool {
+	return models.WorkMode(fl.Field().String()).IsValid()
+}
+
+func validateEmploymentType(fl validator.FieldLevel) bool {
+	return models.EmploymentType(fl.Field().String()).IsValid()
+}
+
+func validateExperienceLevel(fl validator.FieldLevel) bool {
+	return models.ExperienceLevel(fl.Field().String()).IsValid()
+}
+
+func validateMemberRole(fl validator.FieldLevel) bool {
+	return models.MemberRole(fl.Field().String()).IsValid()
+}
+
+// validateAttachmentURL пропускает только http- и https-ссылки: ссылку
+// на вложение открывает собеседник, и javascript: или data: в ней дают
+// XSS. Если задан ATTACHMENT_HOSTS, хост должен входить в этот список.
+func validateAttachmentURL(fl validator.FieldLevel) bool {
+	return isAttachmentURL(fl.Field().String(), os.Getenv("ATTACHMENT_HOSTS"))
+}
+
+// isAttachmentURL проверяет ссылку; hosts — хосты через запятую.
+func isAttachmentURL(raw string, hosts string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.User != nil {
+		return false
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+		return false
+	}
+	if hosts == "" {
 		return true
 	}
+
+	for _, host := range strings.Split(hosts, ",") {
+		if strings.EqualFold(strings.TrimSpace(host), u.Hostname()) {
+			return true
+		}
+	}
 	return false
 }
+
+func validateVacancyCreateSalary(sl validator.StructLevel) {
+	req := sl.Current().Interface().(models.VacancyCreateRequest)
+	validateSalaryRange(sl, req.SalaryMin, req.SalaryMax)
+}
+
+// validateVacancyUpdateSalary проверяет вилку, только если переданы
+// обе границы; с сохранёнными значениями её сверяет сервис.
+func validateVacancyUpdateSalary(sl validator.StructLevel) {
+	req := sl.Current().Interface().(models.VacancyUpdateRequest)
+	validateSalaryRange(sl, req.SalaryMin, req.SalaryMax)
+}
+
+func validateSalaryRange(sl validator.StructLevel, salaryMin, salaryMax *int) {
+	if salaryMin != nil && salaryMax != nil && *salaryMin > *salaryMax {
+		sl.ReportError(salaryMax, "SalaryMax", "salary_max", "salary_range", "")
+	}
+}
@@ -0,0 +1,169 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/ai"
+	"github.com/AliUmarov/team-find-me-job/internal/events"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+)
+
+const (
+	aiJobPollInterval = 2 * time.Second
+	aiJobTimeout      = 90 * time.Second
+	aiJobBaseBackoff  = 10 * time.Second
+	aiJobMaxBackoff   = 10 * time.Minute
+
+	// aiJobStaleAfter — через сколько задача в running считается брошенной
+	// упавшим процессом. Живой воркер укладывается в aiJobTimeout.
+	aiJobStaleAfter = 2 * aiJobTimeout
+)
+
+// AIJobWorker — пул воркеров, разбирающих таблицу ai_jobs.
+type AIJobWorker struct {
+	repo               repository.AIJobRepository
+	resumeService      services.ResumeService
+	applicationService services.ApplicationService
+	broker             events.Broker
+	logger             *slog.Logger
+	concurrency        int
+}
+
+func NewAIJobWorker(
+	repo repository.AIJobRepository,
+	resumeService services.ResumeService,
+	applicationService services.ApplicationService,
+	broker events.Broker,
+	logger *slog.Logger,
+	concurrency int,
+) *AIJobWorker {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	return &AIJobWorker{
+		repo:               repo,
+		resumeService:      resumeService,
+		applicationService: applicationService,
+		broker:             broker,
+		logger:             logger,
+		concurrency:        concurrency,
+	}
+}
+
+// Run блокируется до отмены ctx.
+func (w *AIJobWorker) Run(ctx context.Context) {
+	q := &queue{
+		name:         "ai_jobs",
+		logger:       w.logger,
+		concurrency:  w.concurrency,
+		pollInterval: aiJobPollInterval,
+		timeout:      aiJobTimeout,
+		staleAfter:   aiJobStaleAfter,
+		baseBackoff:  aiJobBaseBackoff,
+		maxBackoff:   aiJobMaxBackoff,
+		claim: func(ctx context.Context) (queueTask, error) {
+			job, err := w.repo.ClaimNext(ctx)
+			if err != nil || job == nil {
+				return nil, err
+			}
+			return &aiJobTask{worker: w, job: job}, nil
+		},
+		requeueStale: w.repo.RequeueStale,
+		retryable:    ai.IsRetryable,
+	}
+	q.run(ctx)
+}
+
+// aiJobTask — ИИ-задача в общей очереди.
+type aiJobTask struct {
+	worker *AIJobWorker
+	job    *models.AIJob
+	result json.RawMessage
+}
+
+func (t *aiJobTask) attrs() []any {
+	return []any{slog.Uint64("job_id", uint64(t.job.ID)), slog.String("type", t.job.Type)}
+}
+
+func (t *aiJobTask) attempts() (int, int) {
+	return t.job.Attempts, t.job.MaxAttempts
+}
+
+func (t *aiJobTask) run(ctx context.Context) error {
+	var err error
+	t.result, err = t.worker.execute(ctx, t.job)
+	return err
+}
+
+func (t *aiJobTask) succeeded(ctx context.Context) error {
+	if err := t.worker.repo.MarkSucceeded(ctx, t.job.ID, t.result); err != nil {
+		return err
+	}
+	t.worker.finished(ctx, t.job, models.AIJobSucceeded, "")
+	return nil
+}
+
+func (t *aiJobTask) retry(ctx context.Context, err error, runAt time.Time) error {
+	return t.worker.repo.MarkRetry(ctx, t.job.ID, err.Error(), runAt)
+}
+
+func (t *aiJobTask) failed(ctx context.Context, err error) error {
+	if markErr := t.worker.repo.MarkFailed(ctx, t.job.ID, err.Error()); markErr != nil {
+		return markErr
+	}
+	t.worker.finished(ctx, t.job, models.AIJobFailed, err.Error())
+	return nil
+}
+
+// finished сообщает владельцу задачи, что она завершилась.
+func (w *AIJobWorker) finished(ctx context.Context, job *models.AIJob, status models.AIJobStatus, errMsg string) {
+	w.broker.Publish(ctx, events.Event{
+		Type: events.TypeAIJobFinished,
+		Data: events.AIJobPayload{
+			JobID:    job.ID,
+			Type:     job.Type,
+			Status:   status,
+			ResumeID: job.ResumeID,
+			Error:    errMsg,
+		},
+	}, events.Recipient{UserID: job.OwnerID, Role: job.OwnerRole})
+}
+
+func (w *AIJobWorker) execute(ctx context.Context, job *models.AIJob) (json.RawMessage, error) {
+	switch job.Type {
+	case models.AIJobTypeImproveResume:
+		resume, err := w.resumeService.ProcessImprove(ctx, job.ResumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(map[string]any{
+			"resume_id":   resume.ID,
+			"ai_improved": resume.AIImproved,
+			"ai_score":    resume.AIScore,
+		})
+
+	case models.AIJobTypeMatchApplication:
+		if job.ApplicationID == nil {
+			return nil, fmt.Errorf("ИИ-задача %d без отклика", job.ID)
+		}
+		app, err := w.applicationService.ProcessMatch(ctx, *job.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(map[string]any{
+			"application_id": app.ID,
+			"match_score":    app.MatchScore,
+		})
+	}
+
+	return nil, fmt.Errorf("неизвестный тип ИИ-задачи %q", job.Type)
+}
@@ -0,0 +1,112 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/mail"
+	"github.com/AliUmarov/team-find-me-job/internal/models"
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+)
+
+const (
+	outboxPollInterval = 5 * time.Second
+	outboxSendTimeout  = 30 * time.Second
+	outboxBaseBackoff  = 30 * time.Second
+	outboxMaxBackoff   = time.Hour
+
+	// outboxStaleAfter — через сколько письмо в sending считается
+	// брошенным упавшим процессом.
+	outboxStaleAfter = 2 * outboxSendTimeout
+)
+
+// OutboxDispatcher отправляет письма из outbox_emails через транспорт.
+type OutboxDispatcher struct {
+	repo      repository.OutboxRepository
+	transport mail.Transport
+	logger    *slog.Logger
+}
+
+func NewOutboxDispatcher(repo repository.OutboxRepository, transport mail.Transport, logger *slog.Logger) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		repo:      repo,
+		transport: transport,
+		logger:    logger,
+	}
+}
+
+// Run блокируется до отмены ctx.
+func (w *OutboxDispatcher) Run(ctx context.Context) {
+	q := &queue{
+		name:         "outbox_emails",
+		logger:       w.logger,
+		concurrency:  1,
+		pollInterval: outboxPollInterval,
+		timeout:      outboxSendTimeout,
+		staleAfter:   outboxStaleAfter,
+		baseBackoff:  outboxBaseBackoff,
+		maxBackoff:   outboxMaxBackoff,
+		claim: func(ctx context.Context) (queueTask, error) {
+			email, err := w.repo.ClaimNext(ctx)
+			if err != nil || email == nil {
+				return nil, err
+			}
+			return &outboxTask{dispatcher: w, email: email}, nil
+		},
+		requeueStale: w.repo.RequeueStale,
+		retryable: func(err error) bool {
+			return !errors.Is(err, errInvalidAttachments)
+		},
+	}
+	q.run(ctx)
+}
+
+// errInvalidAttachments — вложения письма не читаются; повтор не поможет.
+var errInvalidAttachments = errors.New("invalid attachments")
+
+// outboxTask — письмо в общей очереди.
+type outboxTask struct {
+	dispatcher *OutboxDispatcher
+	email      *models.OutboxEmail
+}
+
+func (t *outboxTask) attrs() []any {
+	return []any{slog.Uint64("email_id", uint64(t.email.ID)), slog.String("template", t.email.Template)}
+}
+
+func (t *outboxTask) attempts() (int, int) {
+	return t.email.Attempts, t.email.MaxAttempts
+}
+
+func (t *outboxTask) run(ctx context.Context) error {
+	var attachments []mail.Attachment
+	if len(t.email.Attachments) > 0 {
+		if err := json.Unmarshal(t.email.Attachments, &attachments); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidAttachments, err)
+		}
+	}
+
+	return t.dispatcher.transport.Send(ctx, mail.Message{
+		To:          t.email.To,
+		Subject:     t.email.Subject,
+		HTML:        t.email.HTML,
+		Text:        t.email.Text,
+		Attachments: attachments,
+	})
+}
+
+func (t *outboxTask) succeeded(ctx context.Context) error {
+	return t.dispatcher.repo.MarkSent(ctx, t.email.ID)
+}
+
+func (t *outboxTask) retry(ctx context.Context, err error, runAt time.Time) error {
+	return t.dispatcher.repo.MarkRetry(ctx, t.email.ID, err.Error(), runAt)
+}
+
+func (t *outboxTask) failed(ctx context.Context, err error) error {
+	return t.dispatcher.repo.MarkFailed(ctx, t.email.ID, err.Error())
+}
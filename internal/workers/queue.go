@@ -0,0 +1,181 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// queueTask — запись, забранная из таблицы-очереди.
+type queueTask interface {
+	// attrs — атрибуты записи для логов.
+	attrs() []any
+	attempts() (attempt int, maxAttempts int)
+	// run выполняет запись; ctx ограничен таймаутом очереди.
+	run(ctx context.Context) error
+	// succeeded, retry и failed сохраняют исход. Их ctx не отменяется
+	// остановкой сервера, чтобы исход не потерялся.
+	succeeded(ctx context.Context) error
+	retry(ctx context.Context, err error, runAt time.Time) error
+	failed(ctx context.Context, err error) error
+}
+
+// queue — таблица-очередь с повторами (ai_jobs, outbox_emails). Записи
+// забираются через claim, выполняются с таймаутом и при ошибке
+// возвращаются в очередь с экспоненциальной задержкой. Записи, брошенные
+// упавшим процессом, периодически возвращает requeueStale.
+type queue struct {
+	name        string
+	logger      *slog.Logger
+	concurrency int
+
+	pollInterval time.Duration
+	timeout      time.Duration
+	// staleAfter — через сколько запись в работе считается брошенной.
+	// Должно превышать timeout, иначе живую запись заберут повторно.
+	staleAfter  time.Duration
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	// claim возвращает nil, если готовых записей нет.
+	claim        func(ctx context.Context) (queueTask, error)
+	requeueStale func(ctx context.Context, startedBefore time.Time) (int64, error)
+	// retryable сообщает, стоит ли повторять запись после ошибки; nil — всегда.
+	retryable func(err error) bool
+}
+
+// run блокируется до отмены ctx и дожидается обрабатываемых записей.
+func (q *queue) run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		q.requeueLoop(ctx)
+	}()
+
+	for i := 0; i < max(q.concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.loop(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *queue) requeueLoop(ctx context.Context) {
+	ticker := time.NewTicker(q.staleAfter)
+	defer ticker.Stop()
+
+	for {
+		requeued, err := q.requeueStale(ctx, time.Now().Add(-q.staleAfter))
+		if err != nil && ctx.Err() == nil {
+			q.logger.Error("не удалось вернуть зависшие записи в очередь",
+				slog.String("queue", q.name),
+				slog.Any("error", err),
+			)
+		} else if requeued > 0 {
+			q.logger.Warn("зависшие записи возвращены в очередь",
+				slog.String("queue", q.name),
+				slog.Int64("count", requeued),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *queue) loop(ctx context.Context) {
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Разбираем очередь, пока в ней есть готовые записи.
+		for ctx.Err() == nil {
+			task, err := q.claim(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					q.logger.Error("не удалось получить запись из очереди",
+						slog.String("queue", q.name),
+						slog.Any("error", err),
+					)
+				}
+				break
+			}
+			if task == nil {
+				break
+			}
+			q.process(ctx, task)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *queue) process(ctx context.Context, task queueTask) {
+	runCtx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+
+	err := task.run(runCtx)
+
+	// Исход сохраняется и при остановке сервера, иначе запись
+	// осталась бы в работе до прохода requeueLoop.
+	statusCtx := context.WithoutCancel(ctx)
+	logger := q.logger.With(slog.String("queue", q.name)).With(task.attrs()...)
+	attempt, maxAttempts := task.attempts()
+
+	switch {
+	case err == nil:
+		if err := task.succeeded(statusCtx); err != nil {
+			logger.Error("не удалось сохранить результат записи", slog.Any("error", err))
+		}
+
+	// Запись прервала остановка сервера, а не её собственная ошибка:
+	// возвращаем её в очередь без задержки.
+	case ctx.Err() != nil:
+		logger.Warn("запись прервана остановкой и возвращена в очередь")
+		if err := task.retry(statusCtx, err, time.Now()); err != nil {
+			logger.Error("не удалось вернуть запись в очередь", slog.Any("error", err))
+		}
+
+	case (q.retryable == nil || q.retryable(err)) && attempt < maxAttempts:
+		runAt := time.Now().Add(retryDelay(q.baseBackoff, q.maxBackoff, attempt))
+		logger.Warn("запись будет повторена",
+			slog.Int("attempt", attempt),
+			slog.Time("run_at", runAt),
+			slog.Any("error", err),
+		)
+		if err := task.retry(statusCtx, err, runAt); err != nil {
+			logger.Error("не удалось вернуть запись в очередь", slog.Any("error", err))
+		}
+
+	default:
+		logger.Error("запись завершилась ошибкой",
+			slog.Int("attempt", attempt),
+			slog.Any("error", err),
+		)
+		if markErr := task.failed(statusCtx, err); markErr != nil {
+			logger.Error("не удалось сохранить ошибку записи", slog.Any("error", markErr))
+		}
+	}
+}
+
+// retryDelay — экспоненциальная задержка перед повтором: base, 2·base,
+// 4·base... но не больше limit.
+func retryDelay(base time.Duration, limit time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < limit; i++ {
+		delay *= 2
+	}
+
+	return min(delay, limit)
+}
@@ -0,0 +1,143 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeTask struct {
+	err         error
+	attempt     int
+	maxAttempts int
+	// cancel вызывается во время run, имитируя остановку сервера.
+	cancel context.CancelFunc
+
+	outcome  string
+	runAt    time.Time
+	statusOK bool
+}
+
+func (t *fakeTask) attrs() []any             { return nil }
+func (t *fakeTask) attempts() (int, int)     { return t.attempt, t.maxAttempts }
+func (t *fakeTask) mark(ctx context.Context) { t.statusOK = ctx.Err() == nil }
+
+func (t *fakeTask) run(ctx context.Context) error {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	return t.err
+}
+
+func (t *fakeTask) succeeded(ctx context.Context) error {
+	t.outcome = "succeeded"
+	t.mark(ctx)
+	return nil
+}
+
+func (t *fakeTask) retry(ctx context.Context, _ error, runAt time.Time) error {
+	t.outcome, t.runAt = "retry", runAt
+	t.mark(ctx)
+	return nil
+}
+
+func (t *fakeTask) failed(ctx context.Context, _ error) error {
+	t.outcome = "failed"
+	t.mark(ctx)
+	return nil
+}
+
+func TestQueueProcess(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	q := &queue{
+		name:        "test",
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		timeout:     time.Second,
+		baseBackoff: time.Minute,
+		maxBackoff:  time.Hour,
+		retryable: func(err error) bool {
+			return !errors.Is(err, errPermanent)
+		},
+	}
+
+	tests := []struct {
+		name      string
+		task      fakeTask
+		shutdown  bool
+		want      string
+		wantDelay time.Duration
+	}{
+		{name: "success", task: fakeTask{attempt: 1, maxAttempts: 3}, want: "succeeded"},
+		{
+			name:      "retryable error",
+			task:      fakeTask{err: errors.New("timeout"), attempt: 2, maxAttempts: 3},
+			want:      "retry",
+			wantDelay: 2 * time.Minute,
+		},
+		{
+			name: "attempts exhausted",
+			task: fakeTask{err: errors.New("timeout"), attempt: 3, maxAttempts: 3},
+			want: "failed",
+		},
+		{
+			name: "permanent error",
+			task: fakeTask{err: errPermanent, attempt: 1, maxAttempts: 3},
+			want: "failed",
+		},
+		{
+			name:     "shutdown",
+			task:     fakeTask{err: context.Canceled, attempt: 3, maxAttempts: 3},
+			shutdown: true,
+			want:     "retry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			task := tt.task
+			if tt.shutdown {
+				task.cancel = cancel
+			}
+
+			before := time.Now()
+			q.process(ctx, &task)
+
+			if task.outcome != tt.want {
+				t.Fatalf("outcome = %q, want %q", task.outcome, tt.want)
+			}
+			if !task.statusOK {
+				t.Error("status written with a cancelled context")
+			}
+			if task.outcome == "retry" {
+				if delay := task.runAt.Sub(before); delay < tt.wantDelay || delay > tt.wantDelay+time.Second {
+					t.Errorf("retry delay = %v, want %v", delay, tt.wantDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 10 * time.Second},
+		{attempt: 1, want: 10 * time.Second},
+		{attempt: 2, want: 20 * time.Second},
+		{attempt: 4, want: 80 * time.Second},
+		{attempt: 50, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(10*time.Second, 10*time.Minute, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
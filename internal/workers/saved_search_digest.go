@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/services"
+)
+
+const savedSearchDigestInterval = 5 * time.Minute
+
+// SavedSearchDigester рассылает подборки новых вакансий по сохранённым
+// поискам, срок которых наступил.
+type SavedSearchDigester struct {
+	service services.SavedService
+	logger  *slog.Logger
+}
+
+func NewSavedSearchDigester(service services.SavedService, logger *slog.Logger) *SavedSearchDigester {
+	return &SavedSearchDigester{service: service, logger: logger}
+}
+
+// Run блокируется до отмены ctx.
+func (w *SavedSearchDigester) Run(ctx context.Context) {
+	ticker := time.NewTicker(savedSearchDigestInterval)
+	defer ticker.Stop()
+
+	for {
+		processed := 0
+		for ctx.Err() == nil {
+			claimed, err := w.service.SendNextDigest(ctx)
+			if err != nil {
+				w.logger.Error("не удалось отправить подборку по сохранённому поиску", slog.Any("error", err))
+			}
+			if !claimed {
+				break
+			}
+			processed++
+		}
+		if processed > 0 {
+			w.logger.Info("подборки по сохранённым поискам обработаны", slog.Int("count", processed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
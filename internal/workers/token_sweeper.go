@@ -0,0 +1,80 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+)
+
+const tokenSweepInterval = time.Hour
+
+// TokenSweeper периодически удаляет истёкшие refresh-токены, сессии
+// и одноразовые токены из писем. Использованные refresh-токены живут
+// до своего срока: по ним распознаётся повторное предъявление, поэтому
+// удалять их раньше нельзя.
+type TokenSweeper struct {
+	refreshTokenRepo repository.RefreshTokenRepository
+	sessionRepo      repository.SessionRepository
+	oneTimeTokenRepo repository.OneTimeTokenRepository
+	logger           *slog.Logger
+}
+
+func NewTokenSweeper(
+	refreshTokenRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	oneTimeTokenRepo repository.OneTimeTokenRepository,
+	logger *slog.Logger,
+) *TokenSweeper {
+	return &TokenSweeper{
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		oneTimeTokenRepo: oneTimeTokenRepo,
+		logger:           logger,
+	}
+}
+
+// Run блокируется до отмены ctx.
+func (w *TokenSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(tokenSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *TokenSweeper) sweep(ctx context.Context) {
+	tokens, err := w.refreshTokenRepo.DeleteExpired(ctx, nil)
+	if err != nil {
+		w.logger.Error("не удалось удалить истёкшие refresh-токены", slog.Any("error", err))
+		return
+	}
+
+	sessions, err := w.sessionRepo.DeleteExpired(ctx, nil)
+	if err != nil {
+		w.logger.Error("не удалось удалить истёкшие сессии", slog.Any("error", err))
+		return
+	}
+
+	oneTimeTokens, err := w.oneTimeTokenRepo.DeleteExpired(ctx, nil)
+	if err != nil {
+		w.logger.Error("не удалось удалить истёкшие одноразовые токены", slog.Any("error", err))
+		return
+	}
+
+	if tokens > 0 || sessions > 0 || oneTimeTokens > 0 {
+		w.logger.Info("истёкшие токены удалены",
+			slog.Int64("refresh_tokens", tokens),
+			slog.Int64("sessions", sessions),
+			slog.Int64("one_time_tokens", oneTimeTokens),
+		)
+	}
+}
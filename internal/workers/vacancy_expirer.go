@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/AliUmarov/team-find-me-job/internal/repository"
+)
+
+const vacancyExpiryInterval = time.Minute
+
+// VacancyExpirer закрывает вакансии, у которых наступил ExpiresAt.
+// Поиск скрывает их и раньше, а воркер переводит в closed, чтобы статус
+// видела и компания.
+type VacancyExpirer struct {
+	repo   repository.VacancyRepository
+	logger *slog.Logger
+}
+
+func NewVacancyExpirer(repo repository.VacancyRepository, logger *slog.Logger) *VacancyExpirer {
+	return &VacancyExpirer{repo: repo, logger: logger}
+}
+
+// Run блокируется до отмены ctx.
+func (w *VacancyExpirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(vacancyExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := w.repo.CloseExpired(ctx, time.Now())
+		if err != nil {
+			w.logger.Error("не удалось закрыть истёкшие вакансии", slog.Any("error", err))
+		} else if closed > 0 {
+			w.logger.Info("истёкшие вакансии закрыты", slog.Int64("count", closed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}